@@ -127,15 +127,17 @@ func Box(out io.Writer, headline string, content io.Reader, opts ...BoxStyle) {
 			return []string{text}
 		}
 
+		// The last column of the terminal is kept free, so that the cursor does
+		// not wrap into the next line
 		var (
 			buf       bytes.Buffer
 			lines     = []string{}
-			lineWidth = term.GetTerminalWidth() - len(prefix)
+			lineWidth = term.GetTerminalWidth() - displayWidth(prefix+" ") - 1
 		)
 
 		buf.WriteString(words[0])
 		for _, word := range words[1:] {
-			if len(word)+1 > lineWidth-buf.Len() {
+			if displayWidth(word)+1 > lineWidth-displayWidth(buf.String()) {
 				lines = append(lines, buf.String())
 				buf.Reset()
 				buf.WriteString(word)
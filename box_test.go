@@ -243,6 +243,19 @@ DimGray{╵}
 `)))
 		})
 
+		It("should wrap lines based on the display width of a colored prefix", func() {
+			Expect("\n" + ContentBox(
+				"headline",
+				"content with a very long first line, that is exactly as long as the line can be, and more",
+				HeadlineColor(DodgerBlue),
+			)).To(BeEquivalentTo(Sprintf(`
+DodgerBlue{╭} DodgerBlue{headline}
+DodgerBlue{│} content with a very long first line, that is exactly as long as the line can
+DodgerBlue{│} be, and more
+DodgerBlue{╵}
+`)))
+		})
+
 		It("should not wrap long lines if wrapping is disabled", func() {
 			Expect("\n" + ContentBox(
 				"headline",
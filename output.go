@@ -32,6 +32,7 @@ import (
 	"github.com/lucasb-eyer/go-colorful"
 
 	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/term"
 )
 
 // frequently used output constants
@@ -41,6 +42,7 @@ const (
 	colorBool          = "boolColor"
 	colorComment       = "commentColor"
 	colorDash          = "dashColor"
+	colorDuplicateKey  = "duplicateKeyColor"
 	colorFloat         = "floatColor"
 	colorIndentLine    = "indentLineColor"
 	colorInt           = "intColor"
@@ -55,6 +57,29 @@ const (
 	emptyStructures = "emptyStructures"
 )
 
+// CompactJSONLayout defines how the compact JSON output is laid out
+type CompactJSONLayout int
+
+// Compact JSON output supports three layouts: one line with spaces after each
+// comma and colon, one line without any optional whitespace (minified), and
+// one line per object or list unless it fits into the configured line width.
+const (
+	CompactWithSpaces CompactJSONLayout = iota
+	Minified
+	WrapAtWidth
+)
+
+// DuplicateKeyMode defines how duplicate keys in mappings are handled
+type DuplicateKeyMode int
+
+// Duplicate keys can be ignored (default), result in an error of type
+// `DuplicateKeyError`, or be highlighted in the output using a warning color.
+const (
+	IgnoreDuplicateKeys DuplicateKeyMode = iota
+	FailOnDuplicateKeys
+	HighlightDuplicateKeys
+)
+
 const (
 	emptyList   = "[]"
 	emptyObject = "{}"
@@ -86,6 +111,7 @@ var DefaultColorSchema = map[string]colorful.Color{
 	emptyStructures:    bunt.PaleGoldenrod,
 	colorComment:       bunt.DimGray,
 	colorAnchor:        bunt.CornflowerBlue,
+	colorDuplicateKey:  bunt.Red,
 }
 
 // OutputProcessor provides the functionality to output neat YAML strings using
@@ -99,6 +125,14 @@ type OutputProcessor struct {
 	useIndentLines             bool
 	boldKeys                   bool
 	enforceDocumentStartMarker bool
+
+	compactJSONLayout      CompactJSONLayout
+	inlineSmallCollections bool
+	preserveComments       bool
+	width                  int
+
+	duplicateKeyMode DuplicateKeyMode
+	duplicateKeys    map[interface{}]struct{}
 }
 
 // NewOutputProcessor creates a new output processor including the required
@@ -160,6 +194,41 @@ func (p *OutputProcessor) EnforceDocumentStartMarker(value bool) *OutputProcesso
 	return p
 }
 
+// CompactJSON sets the layout to be used for compact JSON output
+func (p *OutputProcessor) CompactJSON(layout CompactJSONLayout) *OutputProcessor {
+	p.compactJSONLayout = layout
+	return p
+}
+
+// InlineSmallCollections enables a hybrid JSON layout, where objects and lists
+// that only contain scalars are kept in one line as long as they fit into the
+// line width
+func (p *OutputProcessor) InlineSmallCollections(value bool) *OutputProcessor {
+	p.inlineSmallCollections = value
+	return p
+}
+
+// DuplicateKeys sets how duplicate keys in mappings are handled
+func (p *OutputProcessor) DuplicateKeys(mode DuplicateKeyMode) *OutputProcessor {
+	p.duplicateKeyMode = mode
+	return p
+}
+
+// LineWidth sets the maximum line width for layouts that depend on the
+// available space, the terminal width is used if it is not set
+func (p *OutputProcessor) LineWidth(width int) *OutputProcessor {
+	p.width = width
+	return p
+}
+
+func (p *OutputProcessor) lineWidth() int {
+	if p.width > 0 {
+		return p.width
+	}
+
+	return term.GetTerminalWidth()
+}
+
 // colorize returns the given string with the color applied via bunt.
 func (p *OutputProcessor) colorize(colorName string, text string) string {
 	if p.colorSchema != nil {
@@ -183,6 +252,16 @@ func (p *OutputProcessor) colorizef(colorName string, format string, a ...interf
 	return p.colorize(colorName, format)
 }
 
+// keyColor returns the color name to be used for the given key, which is
+// either a key node, or a map item of a map slice
+func (p *OutputProcessor) keyColor(key interface{}) string {
+	if _, ok := p.duplicateKeys[key]; ok {
+		return colorDuplicateKey
+	}
+
+	return colorKey
+}
+
 func (p *OutputProcessor) determineColorByType(obj interface{}) string {
 	color := colorScalarDefault
 
@@ -255,6 +334,115 @@ func (p *OutputProcessor) prefixAdd() string {
 	return p.colorize(colorIndentLine, "  ")
 }
 
+// checkDuplicateKeys looks for duplicate keys in the provided input and either
+// returns an error for the first duplicate, or remembers the duplicate keys so
+// that they can be highlighted when rendered
+func (p *OutputProcessor) checkDuplicateKeys(obj interface{}) error {
+	p.duplicateKeys = nil
+	if p.duplicateKeyMode == IgnoreDuplicateKeys {
+		return nil
+	}
+
+	var finder duplicateKeyFinder
+	finder.walk("", obj)
+
+	switch p.duplicateKeyMode {
+	case FailOnDuplicateKeys:
+		if len(finder.errors) > 0 {
+			return finder.errors[0]
+		}
+
+	case HighlightDuplicateKeys:
+		p.duplicateKeys = finder.keys
+	}
+
+	return nil
+}
+
+type duplicateKeyFinder struct {
+	errors []*DuplicateKeyError
+	keys   map[interface{}]struct{}
+}
+
+type keyLocation struct {
+	path string
+	line int
+}
+
+func (f *duplicateKeyFinder) add(key string, first keyLocation, duplicate keyLocation, ref interface{}) {
+	if f.keys == nil {
+		f.keys = map[interface{}]struct{}{}
+	}
+
+	f.keys[ref] = struct{}{}
+	f.errors = append(f.errors, &DuplicateKeyError{
+		Key:           key,
+		FirstPath:     first.path,
+		FirstLine:     first.line,
+		DuplicatePath: duplicate.path,
+		DuplicateLine: duplicate.line,
+	})
+}
+
+func (f *duplicateKeyFinder) walk(path string, obj interface{}) {
+	switch t := obj.(type) {
+	case yamlv3.Node:
+		f.walk(path, &t)
+
+	case *yamlv3.Node:
+		node := followAlias(t)
+		switch node.Kind {
+		case yamlv3.DocumentNode:
+			for _, content := range node.Content {
+				f.walk(path, content)
+			}
+
+		case yamlv3.MappingNode:
+			seen := map[string]keyLocation{}
+			for i := 0; i < len(node.Content); i += 2 {
+				key := followAlias(node.Content[i])
+				location := keyLocation{path: path + "/" + key.Value, line: key.Line}
+				if first, ok := seen[key.Value]; ok {
+					f.add(key.Value, first, location, node.Content[i])
+				} else {
+					seen[key.Value] = location
+				}
+
+				f.walk(location.path, node.Content[i+1])
+			}
+
+		case yamlv3.SequenceNode:
+			for i, entry := range node.Content {
+				f.walk(fmt.Sprintf("%s/%d", path, i), entry)
+			}
+		}
+
+	case yamlv2.MapSlice:
+		seen := map[string]keyLocation{}
+		for i := range t {
+			key := fmt.Sprint(t[i].Key)
+			location := keyLocation{path: path + "/" + key}
+			if first, ok := seen[key]; ok {
+				f.add(key, first, location, &t[i])
+			} else {
+				seen[key] = location
+			}
+
+			f.walk(location.path, t[i].Value)
+		}
+
+	case []yamlv2.MapSlice:
+		for i, entry := range t {
+			f.walk(fmt.Sprintf("%s/%d", path, i), entry)
+		}
+
+	case []interface{}:
+		for i, entry := range t {
+			f.walk(fmt.Sprintf("%s/%d", path, i), entry)
+		}
+	}
+}
+
 func followAlias(node *yamlv3.Node) *yamlv3.Node {
 	if node != nil && node.Alias != nil {
 		return followAlias(node.Alias)
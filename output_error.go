@@ -0,0 +1,42 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import "fmt"
+
+// DuplicateKeyError is used to describe that a mapping contains the same key more than once
+type DuplicateKeyError struct {
+	Key           string
+	FirstPath     string
+	FirstLine     int
+	DuplicatePath string
+	DuplicateLine int
+}
+
+func (e *DuplicateKeyError) Error() string {
+	if e.FirstLine > 0 && e.DuplicateLine > 0 {
+		return fmt.Sprintf("unable to render output, key %q at %s (line %d) is already defined at %s (line %d)",
+			e.Key, e.DuplicatePath, e.DuplicateLine, e.FirstPath, e.FirstLine)
+	}
+
+	return fmt.Sprintf("unable to render output, key %q at %s is already defined at %s",
+		e.Key, e.DuplicatePath, e.FirstPath)
+}
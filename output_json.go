@@ -21,6 +21,7 @@
 package neat
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -43,112 +44,214 @@ func ToJSONString(obj interface{}) (string, error) {
 // ToJSON processes the provided input object and tries to neatly output it as
 // human readable JSON honoring the preferences provided to the output processor
 func (p *OutputProcessor) ToJSON(obj interface{}) (string, error) {
+	if err := p.checkDuplicateKeys(obj); err != nil {
+		return "", err
+	}
+
+	if p.inlineJSON(0, obj) {
+		p.out.Flush()
+		return p.data.String(), nil
+	}
+
 	return p.neatJSON("", obj)
 }
 
+// ToJSONC processes the provided input object and outputs it as human readable
+// JSON with comments (JSONC), where the comments of YAML nodes are preserved as
+// `//` style comments next to the respective entries
+func (p *OutputProcessor) ToJSONC(obj interface{}) (string, error) {
+	p.preserveComments = true
+	defer func() { p.preserveComments = false }()
+
+	return p.ToJSON(obj)
+}
+
 // ToCompactJSON processed the provided input object and tries to create a as
-// compact as possible output
+// compact as possible output, the exact layout depends on the configured
+// compact JSON layout (see `CompactJSONLayout`)
 func (p *OutputProcessor) ToCompactJSON(obj interface{}) (string, error) {
+	if err := p.checkDuplicateKeys(obj); err != nil {
+		return "", err
+	}
+
+	element, err := p.compactJSON(obj)
+	if err != nil {
+		return "", err
+	}
+
+	switch p.compactJSONLayout {
+	case Minified:
+		return element.flat(",", ":"), nil
+
+	case WrapAtWidth:
+		var buf bytes.Buffer
+		element.wrapped(&buf, "", "", p.lineWidth())
+		return buf.String(), nil
+
+	default:
+		return element.flat(", ", ": "), nil
+	}
+}
+
+// compactJSONElement is the intermediate representation of the compact JSON
+// output, which is required to decide on the layout of objects and lists
+// based on their rendered length
+type compactJSONElement struct {
+	key     string
+	value   string
+	open    string
+	close   string
+	entries []compactJSONElement
+}
+
+func (p *OutputProcessor) compactJSON(obj interface{}) (compactJSONElement, error) {
 	switch tobj := obj.(type) {
 	case *yamlv3.Node:
-		return p.ToCompactJSON(*tobj)
+		return p.compactJSON(*tobj)
 
 	case yamlv3.Node:
 		switch tobj.Kind {
 		case yamlv3.DocumentNode:
-			return p.ToCompactJSON(tobj.Content[0])
+			return p.compactJSON(tobj.Content[0])
 
 		case yamlv3.MappingNode:
-			tmp := []string{}
+			result := compactJSONElement{open: "{", close: "}"}
 			for i := 0; i < len(tobj.Content); i += 2 {
 				k, v := tobj.Content[i], tobj.Content[i+1]
 
-				key, err := p.ToCompactJSON(k)
+				key, err := p.compactJSON(k)
 				if err != nil {
-					return "", err
+					return compactJSONElement{}, err
 				}
 
-				value, err := p.ToCompactJSON(v)
+				value, err := p.compactJSON(v)
 				if err != nil {
-					return "", err
+					return compactJSONElement{}, err
 				}
 
-				tmp = append(tmp, fmt.Sprintf("%s: %s", key, value))
+				value.key = key.value
+				result.entries = append(result.entries, value)
 			}
 
-			return fmt.Sprintf("{%s}", strings.Join(tmp, ", ")), nil
+			return result, nil
 
 		case yamlv3.SequenceNode:
-			tmp := []string{}
+			result := compactJSONElement{open: "[", close: "]"}
 			for _, e := range tobj.Content {
-				entry, err := p.ToCompactJSON(e)
+				entry, err := p.compactJSON(e)
 				if err != nil {
-					return "", err
+					return compactJSONElement{}, err
 				}
 
-				tmp = append(tmp, entry)
+				result.entries = append(result.entries, entry)
 			}
 
-			return fmt.Sprintf("[%s]", strings.Join(tmp, ", ")), nil
+			return result, nil
 
 		case yamlv3.ScalarNode:
 			obj, err := cast(tobj)
 			if err != nil {
-				return "", err
-			}
-
-			bytes, err := json.Marshal(obj)
-			if err != nil {
-				return "", err
+				return compactJSONElement{}, err
 			}
 
-			return string(bytes), nil
+			return p.compactJSON(obj)
 		}
 
 	case []interface{}:
-		result := make([]string, 0)
+		result := compactJSONElement{open: "[", close: "]"}
 		for _, i := range tobj {
-			value, err := p.ToCompactJSON(i)
+			value, err := p.compactJSON(i)
 			if err != nil {
-				return "", err
+				return compactJSONElement{}, err
 			}
-			result = append(result, value)
+			result.entries = append(result.entries, value)
 		}
 
-		return fmt.Sprintf("[%s]", strings.Join(result, ", ")), nil
+		return result, nil
 
 	case yamlv2.MapSlice:
-		result := make([]string, 0)
+		result := compactJSONElement{open: "{", close: "}"}
 		for _, i := range tobj {
-			value, err := p.ToCompactJSON(i)
+			value, err := p.compactJSON(i)
 			if err != nil {
-				return "", err
+				return compactJSONElement{}, err
 			}
-			result = append(result, value)
+			result.entries = append(result.entries, value)
 		}
 
-		return fmt.Sprintf("{%s}", strings.Join(result, ", ")), nil
+		return result, nil
 
 	case yamlv2.MapItem:
-		key, keyError := p.ToCompactJSON(tobj.Key)
+		key, keyError := p.compactJSON(tobj.Key)
 		if keyError != nil {
-			return "", keyError
+			return compactJSONElement{}, keyError
 		}
 
-		value, valueError := p.ToCompactJSON(tobj.Value)
+		value, valueError := p.compactJSON(tobj.Value)
 		if valueError != nil {
-			return "", valueError
+			return compactJSONElement{}, valueError
 		}
 
-		return fmt.Sprintf("%s: %s", key, value), nil
+		value.key = key.value
+		return value, nil
 	}
 
 	bytes, err := json.Marshal(obj)
 	if err != nil {
-		return "", err
+		return compactJSONElement{}, err
+	}
+
+	return compactJSONElement{value: string(bytes)}, nil
+}
+
+func (e compactJSONElement) isScalar() bool {
+	return len(e.open) == 0
+}
+
+// flat renders the element in one line using the provided separators
+func (e compactJSONElement) flat(comma string, colon string) string {
+	var prefix string
+	if len(e.key) > 0 {
+		prefix = e.key + colon
 	}
 
-	return string(bytes), nil
+	if e.isScalar() {
+		return prefix + e.value
+	}
+
+	tmp := make([]string, len(e.entries))
+	for i, entry := range e.entries {
+		tmp[i] = entry.flat(comma, colon)
+	}
+
+	return prefix + e.open + strings.Join(tmp, comma) + e.close
+}
+
+// wrapped renders the element in one line if it fits into the given width,
+// otherwise the entries of the element are rendered in individual lines
+func (e compactJSONElement) wrapped(buf *bytes.Buffer, indent string, suffix string, width int) {
+	line := e.flat(", ", ": ")
+	if e.isScalar() || len(e.entries) == 0 || displayWidth(indent+line+suffix) <= width {
+		buf.WriteString(indent + line + suffix)
+		return
+	}
+
+	buf.WriteString(indent)
+	if len(e.key) > 0 {
+		buf.WriteString(e.key + ": ")
+	}
+
+	buf.WriteString(e.open + "\n")
+	for i, entry := range e.entries {
+		var entrySuffix string
+		if i < len(e.entries)-1 {
+			entrySuffix = ","
+		}
+
+		entry.wrapped(buf, indent+"  ", entrySuffix, width)
+		buf.WriteString("\n")
+	}
+	buf.WriteString(indent + e.close + suffix)
 }
 
 func (p *OutputProcessor) neatJSON(prefix string, obj interface{}) (string, error) {
@@ -207,11 +310,25 @@ func (p *OutputProcessor) neatJSONofNode(prefix string, node *yamlv3.Node) error
 
 			return prefix
 		}
+
+		// Comments are only written in case the node is not in flow style,
+		// since they would otherwise comment out the rest of the line
+		withComments = p.preserveComments && node.Style != yamlv3.FlowStyle
 	)
 
 	switch node.Kind {
 	case yamlv3.DocumentNode:
-		return p.neatJSONofNode(prefix, node.Content[0])
+		if withComments {
+			fmt.Fprint(p.out, p.jsonComment(prefix, node.HeadComment))
+		}
+
+		if err := p.neatJSONofNode(prefix, node.Content[0]); err != nil {
+			return err
+		}
+
+		if withComments && len(node.FootComment) > 0 {
+			fmt.Fprint(p.out, "\n", strings.TrimSuffix(p.jsonComment(prefix, node.FootComment), "\n"))
+		}
 
 	case yamlv3.MappingNode:
 		if len(node.Content) == 0 {
@@ -223,9 +340,22 @@ func (p *OutputProcessor) neatJSONofNode(prefix string, node *yamlv3.Node) error
 		for i := 0; i < len(node.Content); i += 2 {
 			k, v := followAlias(node.Content[i]), followAlias(node.Content[i+1])
 
+			// Line comments can only be placed at the end of the line for
+			// scalar values, otherwise they are used as head comments
+			var headComment, lineComment, footComment = k.HeadComment, k.LineComment, joinComments(k.FootComment, v.FootComment)
+			if p.isScalar(v) {
+				lineComment = joinComments(lineComment, v.LineComment)
+			} else {
+				headComment, lineComment = joinComments(headComment, lineComment, v.HeadComment, v.LineComment), ""
+			}
+
+			if withComments {
+				fmt.Fprint(p.out, p.jsonComment(optionalIndentPrefix(), headComment))
+			}
+
 			fmt.Fprint(p.out,
 				optionalIndentPrefix(),
-				p.colorizef(colorKey, "%q", k.Value), ": ",
+				p.colorizef(p.keyColor(node.Content[i]), "%q", k.Value), ": ",
 			)
 
 			if p.isScalar(v) {
@@ -233,7 +363,7 @@ func (p *OutputProcessor) neatJSONofNode(prefix string, node *yamlv3.Node) error
 					return err
 				}
 
-			} else {
+			} else if !p.inlineJSON(displayWidth(fmt.Sprintf("%s%q: ", optionalIndentPrefix(), k.Value)), v) {
 				if _, err := p.neatJSON(prefix+p.prefixAdd(), v); err != nil {
 					return err
 				}
@@ -243,7 +373,15 @@ func (p *OutputProcessor) neatJSONofNode(prefix string, node *yamlv3.Node) error
 				fmt.Fprint(p.out, ",")
 			}
 
+			if withComments {
+				fmt.Fprint(p.out, p.jsonLineComment(lineComment))
+			}
+
 			fmt.Fprint(p.out, optionalLineBreak())
+
+			if withComments {
+				fmt.Fprint(p.out, p.jsonComment(optionalIndentPrefix(), footComment))
+			}
 		}
 		bunt.Fprint(p.out, optionalPrefixBeforeEnd(), "*}*")
 
@@ -257,6 +395,15 @@ func (p *OutputProcessor) neatJSONofNode(prefix string, node *yamlv3.Node) error
 		for i := range node.Content {
 			entry := followAlias(node.Content[i])
 
+			var headComment, lineComment = entry.HeadComment, entry.LineComment
+			if !p.isScalar(entry) {
+				headComment, lineComment = joinComments(headComment, lineComment), ""
+			}
+
+			if withComments {
+				fmt.Fprint(p.out, p.jsonComment(optionalIndentPrefix(), headComment))
+			}
+
 			if p.isScalar(entry) {
 				if _, err := p.neatJSON(optionalIndentPrefix(), entry); err != nil {
 					return err
@@ -264,8 +411,10 @@ func (p *OutputProcessor) neatJSONofNode(prefix string, node *yamlv3.Node) error
 
 			} else {
 				fmt.Fprint(p.out, prefix, p.prefixAdd())
-				if _, err := p.neatJSON(prefix+p.prefixAdd(), entry); err != nil {
-					return err
+				if !p.inlineJSON(displayWidth(prefix+p.prefixAdd()), entry) {
+					if _, err := p.neatJSON(prefix+p.prefixAdd(), entry); err != nil {
+						return err
+					}
 				}
 			}
 
@@ -273,7 +422,15 @@ func (p *OutputProcessor) neatJSONofNode(prefix string, node *yamlv3.Node) error
 				fmt.Fprint(p.out, ",")
 			}
 
+			if withComments {
+				fmt.Fprint(p.out, p.jsonLineComment(lineComment))
+			}
+
 			fmt.Fprint(p.out, optionalLineBreak())
+
+			if withComments {
+				fmt.Fprint(p.out, p.jsonComment(optionalIndentPrefix(), entry.FootComment))
+			}
 		}
 		bunt.Fprint(p.out, optionalPrefixBeforeEnd(), "*]*")
 
@@ -299,6 +456,50 @@ func (p *OutputProcessor) neatJSONofNode(prefix string, node *yamlv3.Node) error
 	return nil
 }
 
+// jsonComment converts the provided YAML comment into JSONC comment lines
+func (p *OutputProcessor) jsonComment(prefix string, comment string) string {
+	var buf bytes.Buffer
+	for _, line := range strings.Split(comment, "\n") {
+		if line = strings.TrimSpace(line); len(line) == 0 {
+			continue
+		}
+
+		buf.WriteString(prefix)
+		buf.WriteString(p.colorize(colorComment, "//"+strings.TrimPrefix(line, "#")))
+		buf.WriteString("\n")
+	}
+
+	return buf.String()
+}
+
+// jsonLineComment converts the provided YAML line comment into a JSONC comment
+// that can be placed at the end of a line
+func (p *OutputProcessor) jsonLineComment(comment string) string {
+	var parts []string
+	for _, line := range strings.Split(comment, "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			parts = append(parts, strings.TrimSpace(strings.TrimPrefix(line, "#")))
+		}
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return " " + p.colorize(colorComment, "// "+strings.Join(parts, " "))
+}
+
+func joinComments(comments ...string) string {
+	var result []string
+	for _, comment := range comments {
+		if len(comment) > 0 {
+			result = append(result, comment)
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
+
 func (p *OutputProcessor) neatJSONofYAMLMapSlice(prefix string, mapslice yamlv2.MapSlice) error {
 	if len(mapslice) == 0 {
 		_, _ = p.out.WriteString(p.colorize(emptyStructures, emptyObject))
@@ -312,14 +513,14 @@ func (p *OutputProcessor) neatJSONofYAMLMapSlice(prefix string, mapslice yamlv2.
 		keyString := fmt.Sprintf("\"%v\": ", mapitem.Key)
 
 		_, _ = p.out.WriteString(prefix + p.prefixAdd())
-		_, _ = p.out.WriteString(p.colorize(colorKey, keyString))
+		_, _ = p.out.WriteString(p.colorize(p.keyColor(&mapslice[idx]), keyString))
 
 		if p.isScalar(mapitem.Value) {
 			if err := p.neatJSONofScalar("", mapitem.Value); err != nil {
 				return err
 			}
 
-		} else {
+		} else if !p.inlineJSON(displayWidth(prefix+p.prefixAdd()+keyString), mapitem.Value) {
 			if _, err := p.neatJSON(prefix+p.prefixAdd(), mapitem.Value); err != nil {
 				return err
 			}
@@ -355,8 +556,10 @@ func (p *OutputProcessor) neatJSONofSlice(prefix string, list []interface{}) err
 
 		} else {
 			_, _ = p.out.WriteString(prefix + p.prefixAdd())
-			if _, err := p.neatJSON(prefix+p.prefixAdd(), value); err != nil {
-				return err
+			if !p.inlineJSON(displayWidth(prefix+p.prefixAdd()), value) {
+				if _, err := p.neatJSON(prefix+p.prefixAdd(), value); err != nil {
+					return err
+				}
 			}
 		}
 
@@ -379,24 +582,176 @@ func (p *OutputProcessor) neatJSONofScalar(prefix string, obj interface{}) error
 		return nil
 	}
 
-	data, err := json.Marshal(obj)
+	text, err := p.scalarJSON(obj)
 	if err != nil {
 		return err
 	}
 
-	color := p.determineColorByType(obj)
-
 	_, _ = p.out.WriteString(prefix)
-	parts := strings.Split(string(data), "\\n")
+	_, _ = p.out.WriteString(text)
+
+	return nil
+}
+
+func (p *OutputProcessor) scalarJSON(obj interface{}) (string, error) {
+	if obj == nil {
+		return p.colorize(colorNull, "null"), nil
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		buf   bytes.Buffer
+		color = p.determineColorByType(obj)
+		parts = strings.Split(string(data), "\\n")
+	)
+
 	for idx, part := range parts {
-		_, _ = p.out.WriteString(p.colorize(color, part))
+		buf.WriteString(p.colorize(color, part))
 
 		if idx < len(parts)-1 {
-			_, _ = p.out.WriteString(p.colorize(emptyStructures, "\\n"))
+			buf.WriteString(p.colorize(emptyStructures, "\\n"))
 		}
 	}
 
-	return nil
+	return buf.String(), nil
+}
+
+// inlineJSON writes the provided object in one line, if inlining of small
+// collections is enabled, the object is a collection that only contains
+// scalars, and if it fits into the line width with the given starting column
+func (p *OutputProcessor) inlineJSON(column int, obj interface{}) bool {
+	if !p.inlineSmallCollections {
+		return false
+	}
+
+	text, ok := p.inlineJSONOf(obj)
+	if !ok || column+displayWidth(text)+1 > p.lineWidth() {
+		return false
+	}
+
+	_, _ = p.out.WriteString(text)
+	return true
+}
+
+func (p *OutputProcessor) inlineJSONOf(obj interface{}) (string, bool) {
+	var (
+		open, close string
+		entries     []string
+	)
+
+	switch t := obj.(type) {
+	case yamlv3.Node:
+		return p.inlineJSONOf(&t)
+
+	case *yamlv3.Node:
+		node := followAlias(t)
+		if p.preserveComments && hasComments(node) {
+			return "", false
+		}
+
+		switch node.Kind {
+		case yamlv3.DocumentNode:
+			return p.inlineJSONOf(node.Content[0])
+
+		case yamlv3.MappingNode:
+			open, close = "{", "}"
+			for i := 0; i < len(node.Content); i += 2 {
+				k, v := followAlias(node.Content[i]), followAlias(node.Content[i+1])
+				value, ok := p.inlineJSONofScalarNode(v)
+				if !ok {
+					return "", false
+				}
+
+				entries = append(entries, p.colorizef(p.keyColor(node.Content[i]), "%q", k.Value)+": "+value)
+			}
+
+		case yamlv3.SequenceNode:
+			open, close = "[", "]"
+			for _, entry := range node.Content {
+				value, ok := p.inlineJSONofScalarNode(followAlias(entry))
+				if !ok {
+					return "", false
+				}
+
+				entries = append(entries, value)
+			}
+
+		default:
+			return "", false
+		}
+
+	case yamlv2.MapSlice:
+		open, close = "{", "}"
+		for i, mapitem := range t {
+			if !p.isScalar(mapitem.Value) {
+				return "", false
+			}
+
+			value, err := p.scalarJSON(mapitem.Value)
+			if err != nil {
+				return "", false
+			}
+
+			entries = append(entries, p.colorize(p.keyColor(&t[i]), fmt.Sprintf("\"%v\": ", mapitem.Key))+value)
+		}
+
+	case []interface{}:
+		open, close = "[", "]"
+		for _, entry := range t {
+			if !p.isScalar(entry) {
+				return "", false
+			}
+
+			value, err := p.scalarJSON(entry)
+			if err != nil {
+				return "", false
+			}
+
+			entries = append(entries, value)
+		}
+
+	default:
+		return "", false
+	}
+
+	// Empty collections are rendered as empty structures elsewhere
+	if len(entries) == 0 {
+		return "", false
+	}
+
+	return bunt.Style(open, bunt.Bold()) + strings.Join(entries, ", ") + bunt.Style(close, bunt.Bold()), true
+}
+
+func hasComments(node *yamlv3.Node) bool {
+	for _, n := range append([]*yamlv3.Node{node}, node.Content...) {
+		if len(n.HeadComment) > 0 || len(n.LineComment) > 0 || len(n.FootComment) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *OutputProcessor) inlineJSONofScalarNode(node *yamlv3.Node) (string, bool) {
+	if node.Kind != yamlv3.ScalarNode {
+		return "", false
+	}
+
+	obj, err := cast(*node)
+	if err != nil {
+		return "", false
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", false
+	}
+
+	return p.colorize(p.determineColorByType(node), string(data)), true
 }
 
 func cast(node yamlv3.Node) (interface{}, error) {
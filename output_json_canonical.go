@@ -0,0 +1,337 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	yamlv2 "go.yaml.in/yaml/v2"
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+type canonicalMember struct {
+	key   string
+	value interface{}
+}
+
+// ToCanonicalJSON processes the provided input object and creates JSON output
+// according to the JSON Canonicalization Scheme (RFC 8785), which results in a
+// byte-stable representation that is suitable for hashing or signing. Object
+// keys are sorted by their UTF-16 code units, numbers are formatted like
+// ECMAScript does, and no whitespace or text decorations are used. Duplicate
+// keys are not allowed in canonical JSON and always result in an error.
+func (p *OutputProcessor) ToCanonicalJSON(obj interface{}) (string, error) {
+	var finder duplicateKeyFinder
+	if finder.walk("", obj); len(finder.errors) > 0 {
+		return "", finder.errors[0]
+	}
+
+	var buf bytes.Buffer
+	if err := canonicalJSON(&buf, obj); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func canonicalJSON(buf *bytes.Buffer, obj interface{}) error {
+	switch tobj := obj.(type) {
+	case *yamlv3.Node:
+		return canonicalJSONofNode(buf, tobj)
+
+	case yamlv3.Node:
+		return canonicalJSONofNode(buf, &tobj)
+
+	case yamlv2.MapSlice:
+		members := make([]canonicalMember, len(tobj))
+		for i, mapitem := range tobj {
+			members[i] = canonicalMember{key: fmt.Sprint(mapitem.Key), value: mapitem.Value}
+		}
+
+		return canonicalJSONofObject(buf, members)
+
+	case map[string]interface{}:
+		members := make([]canonicalMember, 0, len(tobj))
+		for key, value := range tobj {
+			members = append(members, canonicalMember{key: key, value: value})
+		}
+
+		return canonicalJSONofObject(buf, members)
+
+	case map[interface{}]interface{}:
+		members := make([]canonicalMember, 0, len(tobj))
+		for key, value := range tobj {
+			members = append(members, canonicalMember{key: fmt.Sprint(key), value: value})
+		}
+
+		return canonicalJSONofObject(buf, members)
+
+	case []yamlv2.MapSlice:
+		list := make([]interface{}, len(tobj))
+		for i, mapslice := range tobj {
+			list[i] = mapslice
+		}
+
+		return canonicalJSONofList(buf, list)
+
+	case []interface{}:
+		return canonicalJSONofList(buf, tobj)
+
+	case nil:
+		buf.WriteString("null")
+		return nil
+
+	case bool:
+		buf.WriteString(strconv.FormatBool(tobj))
+		return nil
+
+	case string:
+		writeCanonicalString(buf, tobj)
+		return nil
+
+	case time.Time:
+		writeCanonicalString(buf, tobj.Format(time.RFC3339Nano))
+		return nil
+
+	case json.Number:
+		number, err := tobj.Float64()
+		if err != nil {
+			return err
+		}
+
+		return writeCanonicalNumber(buf, number)
+	}
+
+	switch value := reflect.ValueOf(obj); value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return writeCanonicalNumber(buf, float64(value.Int()))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return writeCanonicalNumber(buf, float64(value.Uint()))
+
+	case reflect.Float32, reflect.Float64:
+		return writeCanonicalNumber(buf, value.Float())
+	}
+
+	// Any other type (i.e. structs, typed maps or slices) is run through the Go
+	// JSON marshaller and read back into generic types, which can then be used
+	// to create the canonical form.
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	var tmp interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&tmp); err != nil {
+		return err
+	}
+
+	return canonicalJSON(buf, tmp)
+}
+
+func canonicalJSONofNode(buf *bytes.Buffer, node *yamlv3.Node) error {
+	node = followAlias(node)
+
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+			return nil
+		}
+
+		return canonicalJSONofNode(buf, node.Content[0])
+
+	case yamlv3.MappingNode:
+		members := make([]canonicalMember, 0, len(node.Content)/2)
+		for i := 0; i < len(node.Content); i += 2 {
+			members = append(members, canonicalMember{
+				key:   followAlias(node.Content[i]).Value,
+				value: node.Content[i+1],
+			})
+		}
+
+		return canonicalJSONofObject(buf, members)
+
+	case yamlv3.SequenceNode:
+		list := make([]interface{}, len(node.Content))
+		for i, entry := range node.Content {
+			list[i] = entry
+		}
+
+		return canonicalJSONofList(buf, list)
+
+	case yamlv3.ScalarNode:
+		// Decoding the scalar supports all YAML notations, for example
+		// hexadecimal numbers, infinity, or binary data
+		var obj interface{}
+		if err := node.Decode(&obj); err != nil {
+			return err
+		}
+
+		return canonicalJSON(buf, obj)
+	}
+
+	return fmt.Errorf("unable to create canonical JSON for node of kind %d", node.Kind)
+}
+
+func canonicalJSONofObject(buf *bytes.Buffer, members []canonicalMember) error {
+	// RFC 8785 requires the keys to be sorted based on their UTF-16 code units
+	sort.SliceStable(members, func(i, j int) bool {
+		return compareUTF16(members[i].key, members[j].key) < 0
+	})
+
+	buf.WriteString("{")
+	for i, member := range members {
+		if i > 0 {
+			// Keys of different types can still end up as the same string
+			if member.key == members[i-1].key {
+				return fmt.Errorf("unable to create canonical JSON, key %q is defined more than once", member.key)
+			}
+
+			buf.WriteString(",")
+		}
+
+		writeCanonicalString(buf, member.key)
+		buf.WriteString(":")
+		if err := canonicalJSON(buf, member.value); err != nil {
+			return err
+		}
+	}
+	buf.WriteString("}")
+
+	return nil
+}
+
+func canonicalJSONofList(buf *bytes.Buffer, list []interface{}) error {
+	buf.WriteString("[")
+	for i, entry := range list {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+
+		if err := canonicalJSON(buf, entry); err != nil {
+			return err
+		}
+	}
+	buf.WriteString("]")
+
+	return nil
+}
+
+// writeCanonicalString writes the string in quotes only escaping characters
+// that must be escaped in JSON, i.e. quotes, backslashes and control characters
+func writeCanonicalString(buf *bytes.Buffer, text string) {
+	const hex = "0123456789abcdef"
+
+	buf.WriteByte('"')
+	for _, r := range text {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+
+		case '\\':
+			buf.WriteString(`\\`)
+
+		case '\b':
+			buf.WriteString(`\b`)
+
+		case '\f':
+			buf.WriteString(`\f`)
+
+		case '\n':
+			buf.WriteString(`\n`)
+
+		case '\r':
+			buf.WriteString(`\r`)
+
+		case '\t':
+			buf.WriteString(`\t`)
+
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[r>>4])
+				buf.WriteByte(hex[r&0xF])
+
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// writeCanonicalNumber writes the number using the ECMAScript number to string
+// conversion as it is mandated by RFC 8785
+func writeCanonicalNumber(buf *bytes.Buffer, number float64) error {
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return fmt.Errorf("unable to create canonical JSON, %v is not a valid JSON number", number)
+	}
+
+	// Negative zero is serialized as zero
+	if number == 0 {
+		buf.WriteString("0")
+		return nil
+	}
+
+	if number < 0 {
+		buf.WriteString("-")
+		number = -number
+	}
+
+	var format byte = 'e'
+	if number >= 1e-6 && number < 1e21 {
+		format = 'f'
+	}
+
+	text := strconv.FormatFloat(number, format, -1, 64)
+
+	// Go writes the exponent with at least two digits (1e+09), while
+	// ECMAScript uses the minimal number of digits (1e+9)
+	if idx := strings.IndexByte(text, 'e'); idx > 0 && text[idx+2] == '0' {
+		text = text[:idx+2] + text[idx+3:]
+	}
+
+	buf.WriteString(text)
+	return nil
+}
+
+func compareUTF16(a, b string) int {
+	x, y := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] != y[i] {
+			return int(x[i]) - int(y[i])
+		}
+	}
+
+	return len(x) - len(y)
+}
@@ -0,0 +1,116 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/neat"
+
+	yamlv2 "go.yaml.in/yaml/v2"
+)
+
+var _ = Describe("Canonical JSON output", func() {
+	Context("create canonical JSON output (RFC 8785)", func() {
+		var toCanonicalJSON = func(obj interface{}) string {
+			result, err := NewOutputProcessorWithDefaults().ToCanonicalJSON(obj)
+			Expect(err).ToNot(HaveOccurred())
+			return result
+		}
+
+		It("should sort keys by UTF-16 code units", func() {
+			Expect(toCanonicalJSON(yml(`{"\u20ac": "Euro Sign", "\r": "Carriage Return", "\ufb33": "Hebrew Letter Dalet With Dagesh", "1": "One", "\U0001F600": "Emoji: Grinning Face", "\u0080": "Control", "\u00f6": "Latin Small Letter O With Diaeresis"}`))).
+				To(Equal("{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\",\"\u20ac\":\"Euro Sign\",\"\U0001F600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}"))
+		})
+
+		It("should format numbers like ECMAScript does", func() {
+			Expect(toCanonicalJSON(yml(`[333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001, -0.0, 1e21, 100, 1e-7]`))).
+				To(Equal(`[333333333.3333333,1e+30,4.5,0.002,1e-27,0,1e+21,100,1e-7]`))
+		})
+
+		It("should only escape characters that must be escaped", func() {
+			Expect(toCanonicalJSON([]interface{}{"\u20ac$\u000F\nA'B\"\\\\\"/<>&"})).
+				To(Equal("[\"\u20ac$\\u000f\\nA'B\\\"\\\\\\\\\\\"/<>&\"]"))
+		})
+
+		It("should create the same output for different input types", func() {
+			type nested struct {
+				Values []float64 `json:"values"`
+				Name   string    `json:"name"`
+			}
+
+			type example struct {
+				Nested  nested `json:"nested"`
+				Enabled bool   `json:"enabled"`
+			}
+
+			expected := `{"enabled":true,"nested":{"name":"foobar","values":[1,2.5]}}`
+
+			Expect(toCanonicalJSON(yml(`
+nested:
+  values: [1, 2.5]
+  name: foobar
+enabled: true
+`))).To(Equal(expected))
+
+			Expect(toCanonicalJSON(yamlv2.MapSlice{
+				{Key: "nested", Value: yamlv2.MapSlice{
+					{Key: "values", Value: []interface{}{1, 2.5}},
+					{Key: "name", Value: "foobar"},
+				}},
+				{Key: "enabled", Value: true},
+			})).To(Equal(expected))
+
+			Expect(toCanonicalJSON(example{
+				Nested:  nested{Values: []float64{1, 2.5}, Name: "foobar"},
+				Enabled: true,
+			})).To(Equal(expected))
+		})
+
+		It("should fail for numbers that cannot be represented in JSON", func() {
+			_, err := NewOutputProcessorWithDefaults().ToCanonicalJSON(yml(`[.nan]`))
+			Expect(err).To(MatchError("unable to create canonical JSON, NaN is not a valid JSON number"))
+
+			_, err = NewOutputProcessorWithDefaults().ToCanonicalJSON(yml(`[.inf]`))
+			Expect(err).To(MatchError("unable to create canonical JSON, +Inf is not a valid JSON number"))
+		})
+
+		It("should support all YAML scalar notations", func() {
+			Expect(toCanonicalJSON(yml(`{hex: 0x1F, octal: 0o17, binary: !!binary aGVsbG8=, empty: ~}`))).
+				To(Equal(`{"binary":"hello","empty":null,"hex":31,"octal":15}`))
+		})
+
+		It("should fail for duplicate keys regardless of the duplicate key mode", func() {
+			_, err := NewOutputProcessorWithDefaults().ToCanonicalJSON(yamlv2.MapSlice{
+				{Key: "a", Value: 1},
+				{Key: "a", Value: 2},
+			})
+			Expect(err).To(MatchError(&DuplicateKeyError{Key: "a", FirstPath: "/a", DuplicatePath: "/a"}))
+
+			_, err = NewOutputProcessorWithDefaults().DuplicateKeys(IgnoreDuplicateKeys).ToCanonicalJSON(yml("a: 1\na: 2\n"))
+			Expect(err).To(BeAssignableToTypeOf(&DuplicateKeyError{}))
+
+			_, err = NewOutputProcessorWithDefaults().ToCanonicalJSON(map[interface{}]interface{}{1: "one", "1": "eins"})
+			Expect(err).To(MatchError(`unable to create canonical JSON, key "1" is defined more than once`))
+		})
+	})
+})
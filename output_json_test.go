@@ -197,6 +197,204 @@ var _ = Describe("JSON output", func() {
 		})
 	})
 
+	Context("create compact JSON output with different layouts", func() {
+		BeforeEach(func() {
+			SetColorSettings(OFF, OFF)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		var example = yml(`{"name": "foobar", "list": [1, 2, 3], "map": {"key": "value", "other": "a somewhat longer value"}}`)
+
+		It("should create minified JSON output", func() {
+			result, err := NewOutputProcessorWithDefaults().CompactJSON(Minified).ToCompactJSON(example)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(`{"name":"foobar","list":[1,2,3],"map":{"key":"value","other":"a somewhat longer value"}}`))
+		})
+
+		It("should create compact JSON output with spaces by default", func() {
+			result, err := NewOutputProcessorWithDefaults().ToCompactJSON(example)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(`{"name": "foobar", "list": [1, 2, 3], "map": {"key": "value", "other": "a somewhat longer value"}}`))
+		})
+
+		It("should keep the output in one line if it fits into the line width", func() {
+			result, err := NewOutputProcessorWithDefaults().CompactJSON(WrapAtWidth).LineWidth(120).ToCompactJSON(example)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(`{"name": "foobar", "list": [1, 2, 3], "map": {"key": "value", "other": "a somewhat longer value"}}`))
+		})
+
+		It("should only break up the objects and lists that do not fit into the line width", func() {
+			result, err := NewOutputProcessorWithDefaults().CompactJSON(WrapAtWidth).LineWidth(40).ToCompactJSON(example)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(`{
+  "name": "foobar",
+  "list": [1, 2, 3],
+  "map": {
+    "key": "value",
+    "other": "a somewhat longer value"
+  }
+}`))
+		})
+	})
+
+	Context("create hybrid JSON output with inlined small collections", func() {
+		BeforeEach(func() {
+			SetColorSettings(OFF, OFF)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		var toJSON = func(width int, obj interface{}) string {
+			result, err := NewOutputProcessorWithDefaults().
+				InlineSmallCollections(true).
+				LineWidth(width).
+				ToJSON(obj)
+
+			Expect(err).ToNot(HaveOccurred())
+			return result
+		}
+
+		It("should keep collections with only scalars in one line", func() {
+			Expect(toJSON(80, yml(`---
+name: foobar
+list:
+- 1
+- 2
+- 3
+map:
+  nested:
+    key: value
+objects:
+- a: 1
+- b: 2
+`))).To(Equal(`{
+  "name": "foobar",
+  "list": [1, 2, 3],
+  "map": {
+    "nested": {"key": "value"}
+  },
+  "objects": [
+    {"a": 1},
+    {"b": 2}
+  ]
+}`))
+		})
+
+		It("should fall back to multi-line output if the collection does not fit into the line width", func() {
+			Expect(toJSON(24, yamlv2.MapSlice{
+				{Key: "short", Value: []interface{}{1, 2, 3}},
+				{Key: "long", Value: []interface{}{"one", "two", "three"}},
+			})).To(Equal(`{
+  "short": [1, 2, 3],
+  "long": [
+    "one",
+    "two",
+    "three"
+  ]
+}`))
+		})
+
+		It("should inline the top-level collection if it fits", func() {
+			Expect(toJSON(80, []interface{}{1, 2, 3})).To(Equal(`[1, 2, 3]`))
+		})
+	})
+
+	Context("create JSON with comments output", func() {
+		BeforeEach(func() {
+			SetColorSettings(OFF, OFF)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		It("should carry over YAML comments as JSONC comments", func() {
+			result, err := NewOutputProcessorWithDefaults().ToJSONC(yml(`# document head
+
+# key head
+name: foobar # name line
+map: # map line
+  # nested head
+  key: value
+  # nested foot
+list:
+# entry head
+- one # one line
+- two
+# list foot
+
+# document foot
+`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(`// document head
+{
+  // key head
+  "name": "foobar", // name line
+  // map line
+  "map": {
+    // nested head
+    "key": "value"
+    // nested foot
+  },
+  "list": [
+    // entry head
+    "one", // one line
+    "two"
+    // list foot
+  ]
+}
+// document foot`))
+		})
+
+		It("should keep the comments of collection values", func() {
+			result, err := NewOutputProcessorWithDefaults().ToJSONC(yml(`list: [1, 2] # list line
+map: {a: 1} # map line
+nested:
+- [3, 4] # entry line
+`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(`{
+  // list line
+  "list": [1,2],
+  // map line
+  "map": {"a": 1},
+  "nested": [
+    // entry line
+    [3,4]
+  ]
+}`))
+		})
+
+		It("should not add comments to the regular JSON output", func() {
+			result, err := NewOutputProcessorWithDefaults().ToJSON(yml(`name: foobar # name line`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(`{
+  "name": "foobar"
+}`))
+		})
+	})
+
+	Context("create JSON with comments output with colors", func() {
+		BeforeEach(func() {
+			SetColorSettings(ON, ON)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		It("should use the comment color of the color schema", func() {
+			result, err := NewOutputProcessor(false, false, &DefaultColorSchema).ToJSONC(yml(`name: foobar # name line`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(ContainSubstring(Sprint("DimGray{// name line}")))
+		})
+	})
+
 	Context("create JSON output with colors", func() {
 		BeforeEach(func() {
 			SetColorSettings(ON, ON)
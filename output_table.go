@@ -0,0 +1,287 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	yamlv2 "go.yaml.in/yaml/v2"
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// tableRecord is one row of a table created from a list of mappings, where the
+// values are stored by their key
+type tableRecord struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// ToTable renders a list of mappings as a table, for example a YAML sequence
+// of objects. The input is either a YAML node, or a list of mappings. The
+// columns are the union of all keys, unless specific key paths are selected
+// using Columns (for example `metadata.name`). Missing values are left empty
+// and values are colored using the color schema.
+func (p *OutputProcessor) ToTable(obj interface{}, tableOptions ...TableOption) (string, error) {
+	records, err := tableRecords(obj)
+	if err != nil {
+		return "", err
+	}
+
+	// Columns are ordered by the first occurrence of their key
+	var (
+		columns []string
+		known   = map[string]struct{}{}
+	)
+
+	for _, record := range records {
+		for _, key := range record.keys {
+			if _, ok := known[key]; !ok {
+				known[key] = struct{}{}
+				columns = append(columns, key)
+			}
+		}
+	}
+
+	var selection = peekOptions(tableOptions)
+	if len(selection.columnPaths) > 0 {
+		columns = selection.columnPaths
+	}
+
+	if len(columns) == 0 {
+		return "", &EmptyTableError{}
+	}
+
+	var (
+		table   = [][]string{make([]string, len(columns))}
+		numeric = make([]bool, len(columns))
+		found   = make([]bool, len(columns))
+	)
+
+	for y, column := range columns {
+		table[0][y] = p.colorize(colorKey, column)
+		numeric[y] = true
+	}
+
+	for _, record := range records {
+		row := make([]string, len(columns))
+		for y, column := range columns {
+			value, ok := record.lookup(column)
+			if !ok {
+				continue
+			}
+
+			found[y] = true
+			if row[y], err = p.tableCell(value); err != nil {
+				return "", err
+			}
+
+			switch p.determineColorByType(value) {
+			case colorInt, colorFloat:
+
+			default:
+				numeric[y] = false
+			}
+		}
+
+		table = append(table, row)
+	}
+
+	var options = []TableOption{HeaderRows(1)}
+	for y, column := range columns {
+		if !found[y] && len(selection.columnPaths) > 0 {
+			return "", &UnknownColumnError{Column: column}
+		}
+
+		if numeric[y] && found[y] {
+			options = append(options, AlignRight(y))
+		}
+	}
+
+	return Table(table, append(options, tableOptions...)...)
+}
+
+// tableCell renders the value as a colored table cell, where mappings and
+// lists are rendered as compact JSON
+func (p *OutputProcessor) tableCell(value interface{}) (string, error) {
+	switch tobj := value.(type) {
+	case nil:
+		return p.colorize(colorNull, "null"), nil
+
+	case *yamlv3.Node:
+		if tobj.Kind == yamlv3.ScalarNode {
+			return p.colorize(p.determineColorByType(tobj), tobj.Value), nil
+		}
+
+	case map[string]interface{}, map[interface{}]interface{}:
+		record, _ := newTableRecord(tobj)
+		mapslice := make(yamlv2.MapSlice, len(record.keys))
+		for i, key := range record.keys {
+			mapslice[i] = yamlv2.MapItem{Key: key, Value: record.values[key]}
+		}
+
+		value = mapslice
+
+	case yamlv2.MapSlice, []interface{}:
+
+	default:
+		return p.colorize(p.determineColorByType(value), fmt.Sprint(value)), nil
+	}
+
+	element, err := p.compactJSON(value)
+	if err != nil {
+		return "", err
+	}
+
+	return element.flat(", ", ": "), nil
+}
+
+// tableRecords converts the supported inputs into a list of records
+func tableRecords(obj interface{}) ([]tableRecord, error) {
+	var items []interface{}
+
+	switch tobj := obj.(type) {
+	case yamlv3.Node:
+		return tableRecords(&tobj)
+
+	case *yamlv3.Node:
+		node := followAlias(tobj)
+		if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+			node = followAlias(node.Content[0])
+		}
+
+		if node.Kind != yamlv3.SequenceNode {
+			return nil, &UnsupportedTableInputError{Type: fmt.Sprintf("YAML node of kind %d", node.Kind)}
+		}
+
+		for _, item := range node.Content {
+			items = append(items, item)
+		}
+
+	case []interface{}:
+		items = tobj
+
+	case []yamlv2.MapSlice:
+		for _, item := range tobj {
+			items = append(items, item)
+		}
+
+	case []map[string]interface{}:
+		for _, item := range tobj {
+			items = append(items, item)
+		}
+
+	default:
+		return nil, &UnsupportedTableInputError{Type: fmt.Sprintf("%T", obj)}
+	}
+
+	var records = make([]tableRecord, 0, len(items))
+	for _, item := range items {
+		record, ok := newTableRecord(item)
+		if !ok {
+			return nil, &UnsupportedTableInputError{Type: fmt.Sprintf("list with element of type %T", item)}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// newTableRecord creates a record from a mapping, it returns false if the
+// input is not a mapping
+func newTableRecord(obj interface{}) (tableRecord, bool) {
+	var record = tableRecord{values: map[string]interface{}{}}
+	var add = func(key string, value interface{}) {
+		if _, ok := record.values[key]; !ok {
+			record.keys = append(record.keys, key)
+		}
+
+		record.values[key] = value
+	}
+
+	switch tobj := obj.(type) {
+	case *yamlv3.Node:
+		node := followAlias(tobj)
+		if node.Kind != yamlv3.MappingNode {
+			return record, false
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			add(node.Content[i].Value, followAlias(node.Content[i+1]))
+		}
+
+	case yamlv2.MapSlice:
+		for _, item := range tobj {
+			add(fmt.Sprint(item.Key), item.Value)
+		}
+
+	case map[string]interface{}:
+		keys := make([]string, 0, len(tobj))
+		for key := range tobj {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+		for _, key := range keys {
+			add(key, tobj[key])
+		}
+
+	case map[interface{}]interface{}:
+		keys := make([]string, 0, len(tobj))
+		values := map[string]interface{}{}
+		for key, value := range tobj {
+			keys = append(keys, fmt.Sprint(key))
+			values[fmt.Sprint(key)] = value
+		}
+
+		sort.Strings(keys)
+		for _, key := range keys {
+			add(key, values[key])
+		}
+
+	default:
+		return record, false
+	}
+
+	return record, true
+}
+
+// lookup returns the value at the given dotted key path
+func (r tableRecord) lookup(path string) (interface{}, bool) {
+	if value, ok := r.values[path]; ok {
+		return value, true
+	}
+
+	// Descend into nested mappings for keys that are a prefix of the path
+	for _, key := range r.keys {
+		if rest, ok := strings.CutPrefix(path, key+"."); ok {
+			if nested, ok := newTableRecord(r.values[key]); ok {
+				if result, ok := nested.lookup(rest); ok {
+					return result, true
+				}
+			}
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,113 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/bunt"
+	. "github.com/gonvenience/neat"
+)
+
+var _ = Describe("Tables from YAML and JSON input", func() {
+	var example = `
+- name: api
+  replicas: 2
+  metadata: {namespace: prod}
+- name: worker
+  enabled: true
+  metadata: {namespace: dev}
+`
+
+	Context("Rendering tables without colors", func() {
+		BeforeEach(func() {
+			SetColorSettings(OFF, OFF)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		It("should use the union of all keys as columns and fill missing cells", func() {
+			result, err := NewOutputProcessorWithDefaults().ToTable(yml(example), HeaderStyle())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEquivalentTo("name   replicas metadata              enabled\n" +
+				"─────────────────────────────────────────────\n" +
+				"api           2 {\"namespace\": \"prod\"}\n" +
+				"worker          {\"namespace\": \"dev\"}  true\n"))
+		})
+
+		It("should use the selected key paths as columns", func() {
+			result, err := NewOutputProcessorWithDefaults().ToTable(yml(example), HeaderStyle(), Columns("metadata.namespace", "name"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEquivalentTo(`metadata.namespace name
+─────────────────────────
+prod               api
+dev                worker
+`))
+		})
+
+		It("should support lists of mappings", func() {
+			result, err := NewOutputProcessorWithDefaults().ToTable([]interface{}{
+				map[string]interface{}{"name": "foo", "size": 1.5},
+				map[string]interface{}{"name": "bar", "tags": []interface{}{"a", "b"}},
+			}, HeaderStyle())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEquivalentTo("name size tags\n" +
+				"────────────────────\n" +
+				"foo   1.5\n" +
+				"bar       [\"a\", \"b\"]\n"))
+		})
+
+		It("should fail for unknown key paths", func() {
+			_, err := NewOutputProcessorWithDefaults().ToTable(yml(example), Columns("spec.name"))
+			Expect(err).To(BeAssignableToTypeOf(&UnknownColumnError{}))
+		})
+
+		It("should fail for input that is not a list of mappings", func() {
+			_, err := NewOutputProcessorWithDefaults().ToTable(yml(`{foo: bar}`))
+			Expect(err).To(BeAssignableToTypeOf(&UnsupportedTableInputError{}))
+
+			_, err = NewOutputProcessorWithDefaults().ToTable([]interface{}{"foo"})
+			Expect(err).To(BeAssignableToTypeOf(&UnsupportedTableInputError{}))
+		})
+	})
+
+	Context("Rendering tables with colors", func() {
+		BeforeEach(func() {
+			SetColorSettings(ON, ON)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		It("should color the values using the color schema", func() {
+			result, err := NewOutputProcessorWithDefaults().ColorSchema(DefaultColorSchema).ToTable(yml(`[{name: foo, count: 42}]`), HeaderStyle())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEquivalentTo(
+				Style("name", Foreground(DefaultColorSchema["keyColor"])) + " " + Style("count", Foreground(DefaultColorSchema["keyColor"])) + "\n" +
+					"──────────\n" +
+					Style("foo", Foreground(DefaultColorSchema["scalarDefaultColor"])) + "     " + Style("42", Foreground(DefaultColorSchema["intColor"])) + "\n"))
+		})
+	})
+})
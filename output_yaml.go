@@ -57,6 +57,10 @@ func ToYAMLString(obj interface{}) (string, error) {
 // ToYAML processes the provided input object and tries to neatly output it as
 // human-readable YAML honoring the preferences provided to the output processor
 func (p *OutputProcessor) ToYAML(obj interface{}) (string, error) {
+	if err := p.checkDuplicateKeys(obj); err != nil {
+		return "", err
+	}
+
 	if err := p.neatYAML("", false, obj); err != nil {
 		return "", err
 	}
@@ -104,7 +108,7 @@ func (p *OutputProcessor) neatYAMLofMapSlice(prefix string, skipIndentOnFirstLin
 			keyString = bunt.Style(keyString, bunt.Bold())
 		}
 
-		_, _ = p.out.WriteString(p.colorize(colorKey, keyString))
+		_, _ = p.out.WriteString(p.colorize(p.keyColor(&mapslice[i]), keyString))
 
 		switch mapitem.Value.(type) {
 		case yamlv2.MapSlice:
@@ -236,7 +240,7 @@ func (p *OutputProcessor) neatYAMLofNode(prefix string, skipIndentOnFirstLine bo
 				fmt.Fprint(p.out, p.colorize(colorComment, key.HeadComment), "\n")
 			}
 			fmt.Fprint(p.out,
-				bunt.Style(p.colorizef(colorKey, "%s:", key.Value), keyStyles...),
+				bunt.Style(p.colorizef(p.keyColor(key), "%s:", key.Value), keyStyles...),
 			)
 
 			value := node.Content[i+1]
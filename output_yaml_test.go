@@ -510,4 +510,66 @@ dependencies:
 			Expect(output).To(Equal(expected))
 		})
 	})
+
+	Context("duplicate keys", func() {
+		var input = `---
+name: foobar
+list:
+- one
+name: duplicate
+`
+
+		It("should ignore duplicate keys by default", func() {
+			result, err := toYAMLString(yml(input))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(`name: foobar
+list:
+- one
+name: duplicate
+`))
+		})
+
+		It("should fail with an error that describes where the duplicate key is", func() {
+			_, err := NewOutputProcessorWithDefaults().
+				DuplicateKeys(FailOnDuplicateKeys).
+				ToYAML(yml(input))
+
+			Expect(err).To(MatchError(&DuplicateKeyError{
+				Key:           "name",
+				FirstPath:     "/name",
+				FirstLine:     2,
+				DuplicatePath: "/name",
+				DuplicateLine: 5,
+			}))
+		})
+
+		It("should fail for duplicate keys in map slices", func() {
+			_, err := NewOutputProcessorWithDefaults().
+				DuplicateKeys(FailOnDuplicateKeys).
+				ToJSON(yamlv2.MapSlice{
+					{Key: "list", Value: []interface{}{
+						yamlv2.MapSlice{{Key: "a", Value: 1}, {Key: "a", Value: 2}},
+					}},
+				})
+
+			Expect(err).To(MatchError(&DuplicateKeyError{
+				Key:           "a",
+				FirstPath:     "/list/0/a",
+				DuplicatePath: "/list/0/a",
+			}))
+		})
+
+		It("should highlight duplicate keys using the duplicate key color", func() {
+			SetColorSettings(ON, ON)
+
+			result, err := NewOutputProcessorWithDefaults().
+				ColorSchema(DefaultColorSchema).
+				DuplicateKeys(HighlightDuplicateKeys).
+				ToYAML(yml(input))
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(ContainSubstring(Sprint("IndianRed{name:} PaleGreen{foobar}")))
+			Expect(result).To(ContainSubstring(Sprint("Red{name:} PaleGreen{duplicate}")))
+		})
+	})
 })
@@ -22,6 +22,8 @@ package neat
 
 import (
 	"bytes"
+	"errors"
+	"strconv"
 	"strings"
 
 	"github.com/gonvenience/bunt"
@@ -33,23 +35,143 @@ type TableOption func(*options)
 // Alignment defines the text alignment option for a table cell.
 type Alignment int
 
-// Table cells support three types of alignment: left, right, center.
+// Table cells support three types of alignment: left, right, center. The
+// automatic alignment is resolved based on the type of the column values (see
+// TableBuilder) and is otherwise identical to left alignment. The decimal
+// alignment aligns numbers on their decimal point and is otherwise identical
+// to right alignment.
 const (
 	Left Alignment = iota
 	Right
 	Center
+	Auto
+	Decimal
+)
+
+// TruncationMode defines which rows are kept when the number of table rows is
+// limited
+type TruncationMode int
+
+// Truncated tables show either the first rows, the last rows, or the first
+// and last rows with a gap in the middle.
+const (
+	KeepHead TruncationMode = iota
+	KeepTail
+	KeepHeadAndTail
+)
+
+// Overflow defines how cell content that exceeds the column width is handled
+type Overflow int
+
+// Cell content that does not fit into the column is either word-wrapped onto
+// multiple lines, or truncated with an ellipsis.
+const (
+	Wrap Overflow = iota
+	Truncate
 )
 
 type options struct {
 	filler            string
 	separator         string
+	border            BorderStyle
+	rowSeparators     bool
 	desiredRowWidth   int
 	columnAlignment   []Alignment
 	errors            []error
 	omitLinefeedAtEnd bool
 	rowLimit          int
+	truncationMode    TruncationMode
+	truncationMarker  string
+	headerRows        int
+	headerStyles      []bunt.StyleOption
+	headerRepeat      int
+	shrinkToWidth     bool
+	targetWidth       int
+	columnOverflow    []Overflow
+	columnMinWidth    []int
+	columnMaxWidth    []int
+	columnPriority    []int
+	columnPaths       []string
+	sortKeys          []sortKey
+	filters           []func(row []string) bool
+	groupColumn       int
+	groupMode         GroupMode
+	footerRows        int
+	columnAggregation []Aggregation
+	summaryLabel      string
+	cellSpans         []cellSpan
+	rowSpans          map[int][]cellSpan
+	cellStylers       []cellStyler
+	decimals          []decimalLayout
+	columnUnits       []bool
+	vertical          bool
+	verticalFallback  bool
+	verticalWidth     int
+	lenientRows       bool
+	lenientFiller     string
+	longRowMode       LongRowMode
 }
 
+// BorderStyle defines the characters used to draw the outer frame of a table
+// and the horizontal rules between rows. Empty fields are not drawn.
+type BorderStyle struct {
+	TopLeft, Top, TopJunction, TopRight             string
+	Left, Vertical, Right                           string
+	MiddleLeft, Middle, MiddleJunction, MiddleRight string
+	BottomLeft, Bottom, BottomJunction, BottomRight string
+
+	markdown bool
+}
+
+// Predefined border styles to be used with the TableBorder option.
+var (
+	NoBorder = BorderStyle{}
+
+	ASCIIBorder = BorderStyle{
+		TopLeft: "+", Top: "-", TopJunction: "+", TopRight: "+",
+		Left: "|", Vertical: "|", Right: "|",
+		MiddleLeft: "+", Middle: "-", MiddleJunction: "+", MiddleRight: "+",
+		BottomLeft: "+", Bottom: "-", BottomJunction: "+", BottomRight: "+",
+	}
+
+	SingleLineBorder = BorderStyle{
+		TopLeft: "┌", Top: "─", TopJunction: "┬", TopRight: "┐",
+		Left: "│", Vertical: "│", Right: "│",
+		MiddleLeft: "├", Middle: "─", MiddleJunction: "┼", MiddleRight: "┤",
+		BottomLeft: "└", Bottom: "─", BottomJunction: "┴", BottomRight: "┘",
+	}
+
+	RoundedBorder = BorderStyle{
+		TopLeft: "╭", Top: "─", TopJunction: "┬", TopRight: "╮",
+		Left: "│", Vertical: "│", Right: "│",
+		MiddleLeft: "├", Middle: "─", MiddleJunction: "┼", MiddleRight: "┤",
+		BottomLeft: "╰", Bottom: "─", BottomJunction: "┴", BottomRight: "╯",
+	}
+
+	DoubleLineBorder = BorderStyle{
+		TopLeft: "╔", Top: "═", TopJunction: "╦", TopRight: "╗",
+		Left: "║", Vertical: "║", Right: "║",
+		MiddleLeft: "╠", Middle: "═", MiddleJunction: "╬", MiddleRight: "╣",
+		BottomLeft: "╚", Bottom: "═", BottomJunction: "╩", BottomRight: "╝",
+	}
+
+	HeavyBorder = BorderStyle{
+		TopLeft: "┏", Top: "━", TopJunction: "┳", TopRight: "┓",
+		Left: "┃", Vertical: "┃", Right: "┃",
+		MiddleLeft: "┣", Middle: "━", MiddleJunction: "╋", MiddleRight: "┫",
+		BottomLeft: "┗", Bottom: "━", BottomJunction: "┻", BottomRight: "┛",
+	}
+
+	// MarkdownBorder renders the table as a GitHub flavored Markdown table, see
+	// TableMarkdown for details
+	MarkdownBorder = BorderStyle{
+		Left: "|", Vertical: "|", Right: "|",
+		MiddleLeft: "|", Middle: "-", MiddleJunction: "|", MiddleRight: "|",
+
+		markdown: true,
+	}
+)
+
 func defaultOptions(cols int) options {
 	alignments := make([]Alignment, cols)
 	for i := 0; i < cols; i++ {
@@ -59,11 +181,44 @@ func defaultOptions(cols int) options {
 	return options{
 		filler:            " ",
 		separator:         " ",
+		border:            NoBorder,
 		desiredRowWidth:   -1,
 		columnAlignment:   alignments,
 		errors:            []error{},
 		omitLinefeedAtEnd: false,
 		rowLimit:          -1,
+		truncationMarker:  "[...]",
+		columnOverflow:    make([]Overflow, cols),
+		columnMinWidth:    make([]int, cols),
+		columnMaxWidth:    make([]int, cols),
+		columnPriority:    make([]int, cols),
+		groupColumn:       -1,
+		columnAggregation: make([]Aggregation, cols),
+		columnUnits:       make([]bool, cols),
+	}
+}
+
+// peekOptions applies the table options to default options, which is used to
+// look up settings that are required before the table itself is known, option
+// errors are reported once the table is rendered
+func peekOptions(tableOptions []TableOption) options {
+	var opts = defaultOptions(0)
+	for _, userOption := range tableOptions {
+		userOption(&opts)
+	}
+
+	return opts
+}
+
+// forEachColumn calls the function for each provided column index and records
+// an error for column indexes that are out of bounds
+func (opts *options) forEachColumn(cols []int, f func(col int)) {
+	for _, col := range cols {
+		if col < 0 || col >= len(opts.columnAlignment) {
+			opts.errors = append(opts.errors, &ColumnIndexIsOutOfBoundsError{col})
+		} else {
+			f(col)
+		}
 	}
 }
 
@@ -81,6 +236,24 @@ func CustomSeparator(separator string) TableOption {
 	}
 }
 
+// TableBorder sets the border style to be used for the outer frame of the
+// table, the column separator is taken from the border style as well
+func TableBorder(style BorderStyle) TableOption {
+	return func(opts *options) {
+		opts.border = style
+		if len(style.Vertical) > 0 {
+			opts.separator = " " + style.Vertical + " "
+		}
+	}
+}
+
+// RowSeparators adds a horizontal rule between all rows of the table
+func RowSeparators() TableOption {
+	return func(opts *options) {
+		opts.rowSeparators = true
+	}
+}
+
 // DesiredWidth sets the desired width of the table
 func DesiredWidth(width int) TableOption {
 	return func(opts *options) {
@@ -88,29 +261,81 @@ func DesiredWidth(width int) TableOption {
 	}
 }
 
+// ShrinkToWidth shrinks the columns of the table so that it fits into the given
+// width (or the terminal width if the given width is zero) by word-wrapping or
+// truncating the cell content
+func ShrinkToWidth(width int) TableOption {
+	return func(opts *options) {
+		opts.shrinkToWidth = true
+		opts.targetWidth = width
+	}
+}
+
+// TruncateColumns truncates cell content with an ellipsis instead of wrapping
+// it onto multiple lines for the given columns (referenced by index)
+func TruncateColumns(cols ...int) TableOption {
+	return func(opts *options) {
+		opts.forEachColumn(cols, func(col int) {
+			opts.columnOverflow[col] = Truncate
+		})
+	}
+}
+
+// ColumnMinWidth sets the width a column will not shrink below for the given
+// columns (referenced by index)
+func ColumnMinWidth(width int, cols ...int) TableOption {
+	return func(opts *options) {
+		opts.forEachColumn(cols, func(col int) {
+			opts.columnMinWidth[col] = width
+		})
+	}
+}
+
+// ColumnMaxWidth sets the width a column will not grow beyond for the given
+// columns (referenced by index), longer content is wrapped or truncated
+func ColumnMaxWidth(width int, cols ...int) TableOption {
+	return func(opts *options) {
+		opts.forEachColumn(cols, func(col int) {
+			opts.columnMaxWidth[col] = width
+		})
+	}
+}
+
+// ColumnPriority sets the priority of the given columns (referenced by index),
+// columns with a lower priority give up space first when the table is shrunk
+func ColumnPriority(priority int, cols ...int) TableOption {
+	return func(opts *options) {
+		opts.forEachColumn(cols, func(col int) {
+			opts.columnPriority[col] = priority
+		})
+	}
+}
+
+// Columns selects the columns (referenced by their dotted path, for example
+// `Metadata.Name` for struct fields or `metadata.name` for mapping keys) and
+// their order when rendering a slice of structs (see TableFromStructs) or a list
+// of mappings (see OutputProcessor.ToTable), plain tables have no column paths
+func Columns(paths ...string) TableOption {
+	return func(opts *options) {
+		opts.columnPaths = paths
+	}
+}
+
 // AlignRight sets alignment to right for the given columns (referenced by index)
 func AlignRight(cols ...int) TableOption {
 	return func(opts *options) {
-		for _, col := range cols {
-			if col < 0 || col >= len(opts.columnAlignment) {
-				opts.errors = append(opts.errors, &ColumnIndexIsOutOfBoundsError{col})
-			} else {
-				opts.columnAlignment[col] = Right
-			}
-		}
+		opts.forEachColumn(cols, func(col int) {
+			opts.columnAlignment[col] = Right
+		})
 	}
 }
 
 // AlignCenter sets alignment to center for the given columns (referenced by index)
 func AlignCenter(cols ...int) TableOption {
 	return func(opts *options) {
-		for _, col := range cols {
-			if col < 0 || col >= len(opts.columnAlignment) {
-				opts.errors = append(opts.errors, &ColumnIndexIsOutOfBoundsError{col})
-			} else {
-				opts.columnAlignment[col] = Center
-			}
-		}
+		opts.forEachColumn(cols, func(col int) {
+			opts.columnAlignment[col] = Center
+		})
 	}
 }
 
@@ -121,103 +346,536 @@ func OmitLinefeedAtTableEnd() TableOption {
 	}
 }
 
-// LimitRows sets a limit at which point the table is truncated
+// LimitRows sets a limit at which point the table is truncated, header rows
+// are not counted and will always be rendered
 func LimitRows(limit int) TableOption {
 	return func(opts *options) {
 		opts.rowLimit = limit
 	}
 }
 
+// RowTruncation sets which rows are kept when the number of rows is limited
+func RowTruncation(mode TruncationMode) TableOption {
+	return func(opts *options) {
+		opts.truncationMode = mode
+	}
+}
+
+// TruncationMarker sets the text shown in place of the omitted rows when the
+// number of rows is limited, a `%d` in the text is replaced with the number of
+// omitted rows, for example `… %d more rows`
+func TruncationMarker(marker string) TableOption {
+	return func(opts *options) {
+		opts.truncationMarker = marker
+	}
+}
+
+// HeaderRows marks the first rows of the table as header rows, which are
+// styled (bold by default) and separated from the other rows with a rule
+func HeaderRows(rows int) TableOption {
+	return func(opts *options) {
+		opts.headerRows = rows
+		if len(opts.headerStyles) == 0 {
+			opts.headerStyles = []bunt.StyleOption{bunt.Bold()}
+		}
+	}
+}
+
+// HeaderStyle sets the styles to be used for the header rows
+func HeaderStyle(styles ...bunt.StyleOption) TableOption {
+	return func(opts *options) {
+		opts.headerStyles = styles
+	}
+}
+
+// RepeatHeader repeats the header rows after every given number of rows, for
+// example to make paginated output more readable
+func RepeatHeader(rows int) TableOption {
+	return func(opts *options) {
+		opts.headerRepeat = rows
+	}
+}
+
 // Table renders a string with a well spaced and aligned table output
 func Table(table [][]string, tableOptions ...TableOption) (string, error) {
-	maxs, err := lookupMaxLengthPerColumn(table)
+	// Markdown tables need escaped cells and a delimiter row with the alignment
+	if peekOptions(tableOptions).border.markdown {
+		return TableMarkdown(table, tableOptions...)
+	}
+
+	table, maxs, options, err := prepareTable(table, tableOptions)
 	if err != nil {
 		return "", err
 	}
 
 	cols := len(maxs)
-	options := defaultOptions(cols)
+	sections := options.split(table)
+	header, body, footer := sections.header, sections.body, sections.footer
 
-	for _, userOption := range tableOptions {
-		userOption(&options)
-	}
-
-	if len(options.errors) > 0 {
-		return "", options.errors[0]
+	if options.vertical {
+		return options.join(renderVertical(sections, options)), nil
 	}
 
 	var (
-		buf      bytes.Buffer
-		idx      = 0
-		rowLimit = len(table)
+		headerLayouts = options.layouts(sections.headerIdx)
+		bodyLayouts   = options.layouts(sections.bodyIdx)
+		footerLayouts = options.layouts(sections.footerIdx)
 	)
 
-	if (options.rowLimit >= 0) && (options.rowLimit < len(table)) {
-		rowLimit = options.rowLimit
+	// Only the rows that are actually rendered define the column widths
+	for y := range maxs {
+		maxs[y] = 0
+	}
+
+	lookupColumnWidths(header, headerLayouts, maxs, options)
+	lookupColumnWidths(body, bodyLayouts, maxs, options)
+	lookupColumnWidths(footer, footerLayouts, maxs, options)
+	options.decimals = lookupDecimalLayouts(sections.rows(), maxs, options)
+
+	for y, max := range options.columnMaxWidth {
+		if max > 0 && maxs[y] > max {
+			maxs[y] = max
+		}
 	}
 
-	for ; idx < rowLimit; idx++ {
-		row := table[idx]
+	if options.shrinkToWidth {
+		if err := shrinkColumns(maxs, options); err != nil {
+			if options.verticalFallback {
+				return options.join(renderVertical(sections, options)), nil
+			}
+
+			return "", err
+		}
+	}
 
-		if options.desiredRowWidth > 0 {
-			rawRowWidth := lookupPlainRowLength(row, maxs, options.separator)
+	if options.desiredRowWidth > 0 {
+		for _, row := range sections.rows() {
+			rawRowWidth := lookupPlainRowLength(row, maxs, options.separator) + options.frameWidth()
 
 			if rawRowWidth > options.desiredRowWidth {
-				return "", &RowLengthExceedsDesiredWidthError{}
+				return "", &RowLengthExceedsDesiredWidthError{Required: rawRowWidth, Desired: options.desiredRowWidth}
 			}
 
 			for y := range row {
 				maxs[y] += (options.desiredRowWidth - rawRowWidth) / cols
 			}
 		}
+	}
 
-		for y, cell := range row {
-			notLastCol := y < len(row)-1
-			fillment := strings.Repeat(
-				options.filler,
-				maxs[y]-bunt.PlainTextLength(cell),
-			)
-
-			switch options.columnAlignment[y] {
-			case Left:
-				buf.WriteString(cell)
-				if notLastCol {
-					buf.WriteString(fillment)
+	var (
+		border  = options.border
+		lines   = []string{}
+		above   = plainLayout(cols)
+		pending = []ruleKind{}
+	)
+
+	// Rules are rendered once the next row is known, so that the junctions
+	// match the merged cells of the rows above and below the rule
+	var addRule = func(kind ruleKind) {
+		pending = append(pending, kind)
+	}
+
+	var flushRules = func(below rowLayout) {
+		for _, kind := range pending {
+			lines = append(lines, renderRule(maxs, options, kind, above, below))
+			above = below
+		}
+
+		pending = pending[:0]
+	}
+
+	var addRow = func(row []string, layout rowLayout) {
+		flushRules(layout)
+		lines = append(lines, renderRow(row, layout, maxs, options)...)
+		above = layout
+	}
+
+	var addLine = func(text string) {
+		flushRules(mergedLayout(cols))
+		lines = append(lines, renderSpanningLine(text, maxs, options))
+		above = mergedLayout(cols)
+	}
+
+	var renderHeader = func() {
+		for i, row := range header {
+			if len(options.headerStyles) > 0 {
+				styled := make([]string, len(row))
+				for y, cell := range row {
+					styled[y] = bunt.Style(cell, options.headerStyles...)
 				}
 
-			case Right:
-				buf.WriteString(fillment)
-				buf.WriteString(cell)
-
-			case Center:
-				x := bunt.PlainTextLength(fillment) / 2
-				buf.WriteString(fillment[:x])
-				buf.WriteString(cell)
-				if notLastCol {
-					buf.WriteString(fillment[x:])
+				row = styled
+			}
+
+			addRow(row, headerLayouts[i])
+		}
+
+		if len(header) > 0 {
+			addRule(middleRule)
+		}
+	}
+
+	if len(border.Top) > 0 {
+		addRule(topRule)
+	}
+
+	renderHeader()
+	var marker = func() {
+		if sections.omitted > 0 {
+			addLine(options.marker(sections.omitted))
+		}
+	}
+
+	for idx, row := range body {
+		if idx == sections.gap {
+			marker()
+		}
+
+		if options.startsGroup(body, idx) {
+			switch options.groupMode {
+			case GroupHeaders:
+				if idx > 0 && len(border.Middle) > 0 {
+					addRule(middleRule)
+				}
+
+				addLine(row[options.groupColumn])
+
+			case GroupSeparators:
+				switch {
+				case idx > 0 && len(border.Middle) > 0:
+					addRule(middleRule)
+
+				case idx > 0 && options.frameWidth() > 0:
+					addRow(make([]string, cols), plainLayout(cols))
+
+				case idx > 0:
+					flushRules(bodyLayouts[idx])
+					lines = append(lines, "")
 				}
 			}
 
-			if notLastCol {
-				buf.WriteString(options.separator)
+			addRow(options.styleRow(row, idx), bodyLayouts[idx])
+			continue
+		}
+
+		switch {
+		case idx > 0 && options.headerRepeat > 0 && idx%options.headerRepeat == 0:
+			if len(border.Middle) > 0 {
+				addRule(middleRule)
 			}
+
+			renderHeader()
+
+		case idx > 0 && options.rowSeparators:
+			addRule(middleRule)
+		}
+
+		addRow(options.styleRow(row, idx), bodyLayouts[idx])
+	}
+
+	if sections.gap == len(body) {
+		marker()
+	}
+
+	if len(footer) > 0 {
+		addRule(middleRule)
+		for i, row := range footer {
+			addRow(row, footerLayouts[i])
+		}
+	}
+
+	if len(border.Bottom) > 0 {
+		addRule(bottomRule)
+	}
+
+	flushRules(plainLayout(cols))
+
+	if options.exceedsVerticalFallbackWidth(lines) {
+		lines = renderVertical(sections, options)
+	}
+
+	return options.join(lines), nil
+}
+
+// join creates the table output from the lines, where a linefeed is added to
+// the end of each line, unless the settings indicate that there must be no
+// linefeed at the last line
+func (opts options) join(lines []string) string {
+	var result = strings.Join(lines, "\n")
+	if !opts.omitLinefeedAtEnd {
+		result += "\n"
+	}
+
+	return result
+}
+
+// prepareTable checks the table and processes the table options, it returns
+// the table (with balanced rows in lenient mode), the maximum length of each
+// column, and the resulting options
+func prepareTable(table [][]string, tableOptions []TableOption) ([][]string, []int, options, error) {
+	if lenient := peekOptions(tableOptions); lenient.lenientRows {
+		table = lenient.balanceRows(table)
+	}
+
+	maxs, err := lookupMaxLengthPerColumn(table)
+	if err != nil {
+		return nil, nil, options{}, err
+	}
+
+	options := defaultOptions(len(maxs))
+	for _, userOption := range tableOptions {
+		userOption(&options)
+	}
+
+	options.indexCellSpans(table)
+	if err := joinErrors(options.errors); err != nil {
+		return nil, nil, options, err
+	}
+
+	return table, maxs, options, nil
+}
+
+// joinErrors combines all errors collected while applying the table options,
+// a single error is returned as-is so that it can still be type-checked
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+
+	case 1:
+		return errs[0]
+	}
+
+	return errors.Join(errs...)
+}
+
+// tableSections contains the rows to be rendered, grouped by their purpose
+type tableSections struct {
+	header [][]string
+	body   [][]string
+	footer [][]string
+
+	// headerIdx, bodyIdx and footerIdx are the indexes of the rows in the input
+	// table, generated rows like the summary row have the index -1
+	headerIdx []int
+	bodyIdx   []int
+	footerIdx []int
+
+	// omitted is the number of rows omitted due to the row limit, which are
+	// represented by a marker placed in front of the body row at index gap
+	omitted int
+	gap     int
+}
+
+// rows returns all rows of all sections
+func (s tableSections) rows() [][]string {
+	var rows = make([][]string, 0, len(s.header)+len(s.body)+len(s.footer))
+	rows = append(rows, s.header...)
+	rows = append(rows, s.body...)
+	rows = append(rows, s.footer...)
+	return rows
+}
+
+// split returns the header, body and footer rows to be rendered, which takes
+// the configured filters, sort order, grouping, aggregations and row limit into
+// account
+func (opts options) split(table [][]string) tableSections {
+	var headerRows = opts.headerRows
+	switch {
+	case headerRows < 0:
+		headerRows = 0
+
+	case headerRows > len(table):
+		headerRows = len(table)
+	}
+
+	var footerRows = min(max(opts.footerRows, 0), len(table)-headerRows)
+
+	var (
+		footerStart = len(table) - footerRows
+		bodyIdx     = opts.arrange(table, headerRows, footerStart)
+		sections    = tableSections{
+			header:    table[:headerRows],
+			headerIdx: indexRange(0, headerRows),
+			footer:    table[footerStart:],
+			footerIdx: indexRange(footerStart, len(table)),
+			gap:       -1,
 		}
+	)
+
+	if summary := opts.summaryRow(pickRows(table, bodyIdx)); summary != nil {
+		sections.footer = append(sections.footer[:len(sections.footer):len(sections.footer)], summary)
+		sections.footerIdx = append(sections.footerIdx, -1)
+	}
 
-		// Make sure to add a linefeed to the end of each line, unless it is
-		// the last line of the table and the settings indicate that there must
-		// be no linefeed at the last line
-		if lastline := idx >= rowLimit-1; !lastline || !options.omitLinefeedAtEnd {
-			// Special case in which the number of table rows is limited, add an
-			// ellipsis to indicate the truncation
-			if lastline && rowLimit >= 0 && rowLimit < len(table) {
-				buf.WriteString("\n[...]")
+	if (opts.rowLimit >= 0) && (opts.rowLimit < len(bodyIdx)) {
+		sections.omitted = len(bodyIdx) - opts.rowLimit
+
+		switch opts.truncationMode {
+		case KeepTail:
+			bodyIdx, sections.gap = bodyIdx[sections.omitted:], 0
+
+		case KeepHeadAndTail:
+			head := (opts.rowLimit + 1) / 2
+			bodyIdx = append(bodyIdx[:head:head], bodyIdx[len(bodyIdx)-(opts.rowLimit-head):]...)
+			sections.gap = head
+
+		default:
+			bodyIdx, sections.gap = bodyIdx[:opts.rowLimit], opts.rowLimit
+		}
+	}
+
+	sections.body, sections.bodyIdx = pickRows(table, bodyIdx), bodyIdx
+	return sections
+}
+
+// indexRange returns the indexes from start (inclusive) to end (exclusive)
+func indexRange(start int, end int) []int {
+	var result = make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		result = append(result, i)
+	}
+
+	return result
+}
+
+// pickRows returns the table rows with the given indexes
+func pickRows(table [][]string, idx []int) [][]string {
+	var rows = make([][]string, len(idx))
+	for i, rowIdx := range idx {
+		rows[i] = table[rowIdx]
+	}
+
+	return rows
+}
+
+// marker returns the text to be shown in place of the omitted rows
+func (opts options) marker(omitted int) string {
+	return strings.ReplaceAll(opts.truncationMarker, "%d", strconv.Itoa(omitted))
+}
+
+// renderSpanningLine creates a line that spans the whole width of the table,
+// for example to introduce a group of rows
+func renderSpanningLine(text string, maxs []int, options options) string {
+	if len(options.border.Right) == 0 {
+		return text
+	}
+
+	var width = spanWidth(maxs, 0, len(maxs), options)
+	return options.border.Left + " " +
+		text + strings.Repeat(" ", max(0, width-cellWidth(text))) +
+		" " + options.border.Right
+}
+
+// renderRow renders the row into one or more lines, depending on whether cell
+// content has to be wrapped to fit into the column width
+func renderRow(row []string, layout rowLayout, maxs []int, options options) []string {
+	var (
+		cells  = make([][]string, len(row))
+		height = 1
+	)
+
+	for y, cell := range row {
+		if layout.spans[y] == 0 || layout.covered[y] {
+			continue
+		}
+
+		if options.columnAlignment[y] == Decimal && layout.spans[y] == 1 {
+			cell = options.decimals[y].alignDecimal(cell, options.columnUnits[y])
+		}
+
+		cells[y] = layoutCell(cell, spanWidth(maxs, y, layout.spans[y], options), options.columnOverflow[y])
+		if len(cells[y]) > height {
+			height = len(cells[y])
+		}
+	}
+
+	var lines = make([]string, height)
+	for i := range lines {
+		line := make([]string, len(row))
+		for y := range cells {
+			if i < len(cells[y]) {
+				line[y] = cells[y][i]
 			}
+		}
 
-			buf.WriteString("\n")
+		lines[i] = renderLine(line, layout, maxs, options)
+
+		// Lines of unframed tables must not end with whitespace caused by empty
+		// cells, neither with the padding of decimal alignment
+		if len(options.border.Right) == 0 && (i > 0 || len(row) > 0 && (len(row[len(row)-1]) == 0 || options.columnAlignment[len(row)-1] == Decimal)) {
+			lines[i] = strings.TrimRight(lines[i], " ")
+		}
+	}
+
+	return lines
+}
+
+func renderLine(row []string, layout rowLayout, maxs []int, options options) string {
+	var (
+		buf       bytes.Buffer
+		withFrame = len(options.border.Right) > 0
+	)
+
+	if len(options.border.Left) > 0 {
+		buf.WriteString(options.border.Left)
+		buf.WriteString(" ")
+	}
+
+	for y, cell := range row {
+		span := layout.spans[y]
+		if span == 0 {
+			continue
+		}
+
+		notLastCol := y+span < len(row)
+		fill := max(0, spanWidth(maxs, y, span, options)-displayWidth(cell))
+		fillment := strings.Repeat(options.filler, fill)
+
+		switch options.columnAlignment[y] {
+		case Left, Auto:
+			buf.WriteString(cell)
+			if notLastCol || withFrame {
+				buf.WriteString(fillment)
+			}
+
+		case Right, Decimal:
+			buf.WriteString(fillment)
+			buf.WriteString(cell)
+
+		case Center:
+			x := fill / 2
+			buf.WriteString(strings.Repeat(options.filler, x))
+			buf.WriteString(cell)
+			if notLastCol || withFrame {
+				buf.WriteString(strings.Repeat(options.filler, fill-x))
+			}
+		}
+
+		if notLastCol {
+			buf.WriteString(options.separator)
 		}
 	}
 
-	return buf.String(), nil
+	if withFrame {
+		buf.WriteString(" ")
+		buf.WriteString(options.border.Right)
+	}
+
+	return buf.String()
+}
+
+func (opts options) frameWidth() int {
+	var width int
+	if len(opts.border.Left) > 0 {
+		width += displayWidth(opts.border.Left) + 1
+	}
+
+	if len(opts.border.Right) > 0 {
+		width += displayWidth(opts.border.Right) + 1
+	}
+
+	return width
 }
 
 func lookupMaxLengthPerColumn(table [][]string) ([]int, error) {
@@ -226,16 +884,16 @@ func lookupMaxLengthPerColumn(table [][]string) ([]int, error) {
 	}
 
 	cols := len(table[0])
-	for _, row := range table {
+	for i, row := range table {
 		if len(row) != cols {
-			return nil, &ImbalancedTableError{}
+			return nil, &ImbalancedTableError{Row: i, Expected: cols, Actual: len(row)}
 		}
 	}
 
 	maxs := make([]int, cols)
 	for _, row := range table {
 		for y, cell := range row {
-			if max := bunt.PlainTextLength(cell); max > maxs[y] {
+			if max := cellWidth(cell); max > maxs[y] {
 				maxs[y] = max
 			}
 		}
@@ -248,7 +906,11 @@ func lookupPlainRowLength(row []string, maxs []int, separator string) int {
 	var length int
 
 	for i := range row {
-		length += maxs[i] + bunt.PlainTextLength(separator)
+		if i > 0 {
+			length += displayWidth(separator)
+		}
+
+		length += maxs[i]
 	}
 
 	return length
@@ -0,0 +1,219 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// CellFormatter converts a value into the text to be shown in a table cell
+type CellFormatter func(value interface{}) string
+
+// TableBuilder creates tables based on typed values, which are converted into
+// text using per column formatters
+type TableBuilder struct {
+	columns []tableBuilderColumn
+	rows    [][]interface{}
+}
+
+type tableBuilderColumn struct {
+	name      string
+	alignment Alignment
+	formatter CellFormatter
+}
+
+// NewTableBuilder creates a new empty table builder
+func NewTableBuilder() *TableBuilder {
+	return &TableBuilder{}
+}
+
+// AddColumn adds a column with the given name (used in the header row) to the
+// table. In case the alignment is Auto, columns with only numeric values are
+// aligned to the right. If no formatter is provided, the default formatting
+// for common types like numbers, durations, or timestamps is used.
+func (b *TableBuilder) AddColumn(name string, alignment Alignment, formatter CellFormatter) *TableBuilder {
+	if formatter == nil {
+		formatter = FormatValue
+	}
+
+	b.columns = append(b.columns, tableBuilderColumn{
+		name:      name,
+		alignment: alignment,
+		formatter: formatter,
+	})
+
+	return b
+}
+
+// AddRow adds a row with one value per column to the table
+func (b *TableBuilder) AddRow(values ...interface{}) *TableBuilder {
+	b.rows = append(b.rows, values)
+	return b
+}
+
+// Render renders the table using the configured columns and rows, the column
+// names are used as the header row
+func (b *TableBuilder) Render(tableOptions ...TableOption) (string, error) {
+	var (
+		table   = [][]string{}
+		options = []TableOption{}
+	)
+
+	if b.hasHeader() {
+		header := make([]string, len(b.columns))
+		for i, column := range b.columns {
+			header[i] = column.name
+		}
+
+		table = append(table, header)
+		options = append(options, HeaderRows(1))
+	}
+
+	var lenient = peekOptions(tableOptions).lenientRows
+	for i, values := range b.rows {
+		if len(values) != len(b.columns) && !lenient {
+			return "", &ImbalancedTableError{Row: i, Expected: len(b.columns), Actual: len(values)}
+		}
+
+		// In lenient mode, the table balances the rows, and additional values
+		// that have no column use the default formatting
+		row := make([]string, len(values))
+		for i, value := range values {
+			if i < len(b.columns) {
+				row[i] = b.columns[i].formatter(value)
+			} else {
+				row[i] = FormatValue(value)
+			}
+		}
+
+		table = append(table, row)
+	}
+
+	for i, column := range b.columns {
+		switch b.alignment(i, column) {
+		case Right:
+			options = append(options, AlignRight(i))
+
+		case Center:
+			options = append(options, AlignCenter(i))
+
+		case Decimal:
+			options = append(options, AlignDecimal(i))
+		}
+	}
+
+	return Table(table, append(options, tableOptions...)...)
+}
+
+func (b *TableBuilder) hasHeader() bool {
+	for _, column := range b.columns {
+		if len(column.name) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *TableBuilder) alignment(idx int, column tableBuilderColumn) Alignment {
+	if column.alignment != Auto {
+		return column.alignment
+	}
+
+	var numeric bool
+	for _, values := range b.rows {
+		if idx >= len(values) || values[idx] == nil {
+			continue
+		}
+
+		if !isNumeric(values[idx]) {
+			return Left
+		}
+
+		numeric = true
+	}
+
+	if numeric {
+		return Right
+	}
+
+	return Left
+}
+
+// FormatValue is the default cell formatter, which converts numbers, booleans,
+// timestamps, durations, and types that implement the Stringer interface into
+// text
+func FormatValue(value interface{}) string {
+	switch tValue := value.(type) {
+	case nil:
+		return ""
+
+	case string:
+		return tValue
+
+	case bool:
+		return strconv.FormatBool(tValue)
+
+	case time.Time:
+		return tValue.Format(time.RFC3339)
+
+	case time.Duration:
+		return tValue.String()
+
+	case float32:
+		return strconv.FormatFloat(float64(tValue), 'f', -1, 32)
+
+	case float64:
+		return strconv.FormatFloat(tValue, 'f', -1, 64)
+
+	case fmt.Stringer:
+		return tValue.String()
+
+	case error:
+		return tValue.Error()
+	}
+
+	return fmt.Sprint(value)
+}
+
+func isNumeric(value interface{}) bool {
+	// Types with a custom string representation (like enumerations) are not
+	// considered to be numeric, with durations being the exception
+	switch value.(type) {
+	case time.Duration:
+		return true
+
+	case fmt.Stringer:
+		return false
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,103 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/bunt"
+	. "github.com/gonvenience/neat"
+)
+
+type status int
+
+func (s status) String() string {
+	return [...]string{"pending", "running", "done"}[s]
+}
+
+var _ = Describe("Table builder", func() {
+	BeforeEach(func() {
+		SetColorSettings(OFF, OFF)
+	})
+
+	AfterEach(func() {
+		SetColorSettings(AUTO, AUTO)
+	})
+
+	Context("Creating tables from typed values", func() {
+		It("should format values based on their type", func() {
+			started := time.Date(2024, 2, 29, 12, 30, 0, 0, time.UTC)
+
+			tableString, err := NewTableBuilder().
+				AddColumn("name", Auto, nil).
+				AddColumn("count", Auto, nil).
+				AddColumn("ratio", Auto, nil).
+				AddColumn("duration", Auto, nil).
+				AddColumn("enabled", Auto, nil).
+				AddColumn("status", Auto, nil).
+				AddColumn("started", Left, nil).
+				AddRow("foo", 1, 0.5, 90*time.Second, true, status(1), started).
+				AddRow("foobar", 42, 12.25, 250*time.Millisecond, false, status(2), started).
+				Render()
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`name   count ratio duration enabled status  started
+────────────────────────────────────────────────────────────────
+foo        1   0.5    1m30s true    running 2024-02-29T12:30:00Z
+foobar    42 12.25    250ms false   done    2024-02-29T12:30:00Z
+`))
+		})
+
+		It("should use custom formatters and alignments", func() {
+			tableString, err := NewTableBuilder().
+				AddColumn("name", Right, func(value interface{}) string {
+					return strings.ToUpper(value.(string))
+				}).
+				AddColumn("size", Left, func(value interface{}) string {
+					return fmt.Sprintf("%d MiB", value.(int)/1024/1024)
+				}).
+				AddRow("foo", 4*1024*1024).
+				AddRow("foobar", 128*1024*1024).
+				Render(OmitLinefeedAtTableEnd())
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`  name size
+──────────────
+   FOO 4 MiB
+FOOBAR 128 MiB`))
+		})
+
+		It("should fail if a row does not have a value for each column", func() {
+			_, err := NewTableBuilder().
+				AddColumn("name", Auto, nil).
+				AddColumn("size", Auto, nil).
+				AddRow("foo").
+				Render()
+
+			Expect(err).To(MatchError(&ImbalancedTableError{Row: 0, Expected: 2, Actual: 1}))
+		})
+	})
+})
@@ -0,0 +1,151 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"regexp"
+	"strings"
+)
+
+var decimalNumber = regexp.MustCompile(`^([+-]?(?:\d[\d,']*)?)(\.\d*)?([ \t]*)(.*)$`)
+
+// decimalLayout contains the widths of the parts of the numbers in a column
+// with decimal alignment
+type decimalLayout struct {
+	integer  int
+	fraction int
+	space    int
+	suffix   int
+}
+
+// AlignDecimal aligns the numbers of the given columns (referenced by index) on
+// their decimal point, other cell content is aligned to the right
+func AlignDecimal(cols ...int) TableOption {
+	return func(opts *options) {
+		opts.forEachColumn(cols, func(col int) {
+			opts.columnAlignment[col] = Decimal
+		})
+	}
+}
+
+// AlignUnits aligns the numbers of the given columns (referenced by index) on
+// their decimal point like AlignDecimal, and aligns their unit suffixes (e.g.
+// `MiB` or `ms`) as well
+func AlignUnits(cols ...int) TableOption {
+	return func(opts *options) {
+		opts.forEachColumn(cols, func(col int) {
+			opts.columnAlignment[col] = Decimal
+			opts.columnUnits[col] = true
+		})
+	}
+}
+
+// splitDecimal splits the cell into the integer part, the fraction including
+// the decimal point, the whitespace before the suffix, and the suffix, it
+// returns false if the cell does not start with a number. Unless units are
+// aligned, the suffix is considered to be part of the fraction.
+func splitDecimal(cell string, units bool) (string, string, string, string, bool) {
+	match := decimalNumber.FindStringSubmatch(strings.TrimSpace(plainCell(cell)))
+	if match == nil {
+		return "", "", "", "", false
+	}
+
+	integer, fraction, space, suffix := match[1], match[2], match[3], match[4]
+	if len(strings.TrimLeft(integer, "+-")) == 0 && len(fraction) < 2 {
+		return "", "", "", "", false
+	}
+
+	if !units {
+		return integer, fraction + space + suffix, "", "", true
+	}
+
+	return integer, fraction, space, suffix, true
+}
+
+// lookupDecimalLayouts calculates the widths of the number parts for all
+// columns with decimal alignment and makes sure the column widths fit them
+func lookupDecimalLayouts(rows [][]string, maxs []int, options options) []decimalLayout {
+	var layouts = make([]decimalLayout, len(maxs))
+	for y, alignment := range options.columnAlignment {
+		if alignment != Decimal {
+			continue
+		}
+
+		for _, row := range rows {
+			if integer, fraction, space, suffix, ok := splitDecimal(row[y], options.columnUnits[y]); ok {
+				layouts[y].integer = max(layouts[y].integer, displayWidth(integer))
+				layouts[y].fraction = max(layouts[y].fraction, displayWidth(fraction))
+				layouts[y].space = max(layouts[y].space, displayWidth(space))
+				layouts[y].suffix = max(layouts[y].suffix, displayWidth(suffix))
+			}
+		}
+
+		maxs[y] = max(maxs[y], layouts[y].width())
+	}
+
+	return layouts
+}
+
+// width returns the width of the numbers aligned by the layout
+func (l decimalLayout) width() int {
+	return l.integer + l.fraction + l.space + l.suffix
+}
+
+// alignDecimal pads the cell so that its decimal point, and optionally its unit
+// suffix, are at the same position as in all other cells of the column
+func (l decimalLayout) alignDecimal(cell string, units bool) string {
+	integer, fraction, space, suffix, ok := splitDecimal(cell, units)
+	if !ok {
+		return cell
+	}
+
+	var (
+		number  = len(integer) + len(fraction)
+		padding = strings.Repeat(" ", l.fraction-displayWidth(fraction)+l.space-displayWidth(space))
+		padded  = insertAtPlainOffset(strings.TrimSpace(cell), number, padding)
+	)
+
+	return strings.Repeat(" ", l.integer-displayWidth(integer)) +
+		padded +
+		strings.Repeat(" ", l.suffix-displayWidth(suffix))
+}
+
+// insertAtPlainOffset inserts the text at the given byte offset of the plain
+// text, escape sequences of text styles are skipped when counting the offset
+func insertAtPlainOffset(cell string, offset int, text string) string {
+	var count int
+	for i := 0; i < len(cell); i++ {
+		if count == offset {
+			return cell[:i] + text + cell[i:]
+		}
+
+		if cell[i] == '\x1b' {
+			if end := strings.IndexByte(cell[i:], 'm'); end >= 0 {
+				i += end
+				continue
+			}
+		}
+
+		count++
+	}
+
+	return cell + text
+}
@@ -32,18 +32,33 @@ func (e *EmptyTableError) Error() string {
 
 // ImbalancedTableError is used to describe that not all rows have the same number of columns
 type ImbalancedTableError struct {
+	Row      int
+	Expected int
+	Actual   int
 }
 
 func (e *ImbalancedTableError) Error() string {
-	return "unable to render table, some rows have more or less columns than other rows"
+	return fmt.Sprintf("unable to render table, row %d has %d columns, but %d columns are expected", e.Row, e.Actual, e.Expected)
+}
+
+// ColumnWidthsMismatchError is used to describe that the number of configured column widths does not match the number of columns
+type ColumnWidthsMismatchError struct {
+	Columns int
+	Widths  int
+}
+
+func (e *ColumnWidthsMismatchError) Error() string {
+	return fmt.Sprintf("unable to render table, %d column widths are configured, but the table has %d columns", e.Widths, e.Columns)
 }
 
 // RowLengthExceedsDesiredWidthError is used to describe that the table cannot be rendered, because at least one row exceeds the desired width
 type RowLengthExceedsDesiredWidthError struct {
+	Required int
+	Desired  int
 }
 
 func (e *RowLengthExceedsDesiredWidthError) Error() string {
-	return "unable to render table, because at least one row exceeds the desired width"
+	return fmt.Sprintf("unable to render table, because at least one row requires a width of %d, which exceeds the desired width of %d", e.Required, e.Desired)
 }
 
 // ColumnIndexIsOutOfBoundsError is used to describe that a provided column index is out of bounds
@@ -54,3 +69,58 @@ type ColumnIndexIsOutOfBoundsError struct {
 func (e *ColumnIndexIsOutOfBoundsError) Error() string {
 	return fmt.Sprintf("unable to render table, the provided column index %d is out of bounds", e.ColumnIdx)
 }
+
+// RowIndexIsOutOfBoundsError is used to describe that a provided row index is out of bounds
+type RowIndexIsOutOfBoundsError struct {
+	RowIdx int
+}
+
+func (e *RowIndexIsOutOfBoundsError) Error() string {
+	return fmt.Sprintf("unable to render table, the provided row index %d is out of bounds", e.RowIdx)
+}
+
+// OverlappingCellSpansError is used to describe that a merged cell overlaps with another merged cell
+type OverlappingCellSpansError struct {
+	RowIdx    int
+	ColumnIdx int
+}
+
+func (e *OverlappingCellSpansError) Error() string {
+	return fmt.Sprintf("unable to render table, the merged cell at row %d and column %d overlaps with another merged cell", e.RowIdx, e.ColumnIdx)
+}
+
+// ClosedTableWriterError is used to describe that rows are written to a table writer that is already closed
+type ClosedTableWriterError struct {
+}
+
+func (e *ClosedTableWriterError) Error() string {
+	return "unable to render table, the table writer is already closed"
+}
+
+// UnsupportedTableInputError is used to describe that the input cannot be rendered as a table
+type UnsupportedTableInputError struct {
+	Type string
+}
+
+func (e *UnsupportedTableInputError) Error() string {
+	return fmt.Sprintf("unable to render table, input of type %s is not supported", e.Type)
+}
+
+// UnknownColumnError is used to describe that a column referenced by name or path does not exist
+type UnknownColumnError struct {
+	Column string
+}
+
+func (e *UnknownColumnError) Error() string {
+	return fmt.Sprintf("unable to render table, there is no column %s", e.Column)
+}
+
+// InvalidStructTagError is used to describe that a struct field has an invalid neat struct tag
+type InvalidStructTagError struct {
+	Field string
+	Tag   string
+}
+
+func (e *InvalidStructTagError) Error() string {
+	return fmt.Sprintf("unable to render table, field %s has an invalid struct tag %q", e.Field, e.Tag)
+}
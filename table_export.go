@@ -0,0 +1,377 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+)
+
+// TableMarkdown renders the table as a GitHub flavored Markdown table, where
+// the first row is used as the header row and the column alignment is set in
+// the delimiter row
+func TableMarkdown(table [][]string, tableOptions ...TableOption) (string, error) {
+	table, _, options, err := prepareTable(table, tableOptions)
+	if err != nil {
+		return "", err
+	}
+
+	// Markdown tables require a header row, use the first row if none is configured
+	if options.headerRows < 1 {
+		options.headerRows = 1
+	}
+
+	rows := options.split(table).rows()
+
+	var cells = make([][]string, len(rows))
+	var maxs = make([]int, len(table[0]))
+	for i, row := range rows {
+		cells[i] = make([]string, len(row))
+		for y, cell := range row {
+			cells[i][y] = markdownEscape(cell)
+			maxs[y] = max(maxs[y], displayWidth(cells[i][y]), 3)
+		}
+	}
+
+	var buf bytes.Buffer
+	var writeRow = func(row []string) {
+		buf.WriteString("|")
+		for y, cell := range row {
+			buf.WriteString(" ")
+			buf.WriteString(cell)
+			buf.WriteString(strings.Repeat(" ", maxs[y]-displayWidth(cell)))
+			buf.WriteString(" |")
+		}
+		buf.WriteString("\n")
+	}
+
+	writeRow(cells[0])
+
+	buf.WriteString("|")
+	for y, width := range maxs {
+		switch options.columnAlignment[y] {
+		case Right, Decimal:
+			buf.WriteString(" " + strings.Repeat("-", width-1) + ": |")
+
+		case Center:
+			buf.WriteString(" :" + strings.Repeat("-", width-2) + ": |")
+
+		default:
+			buf.WriteString(" " + strings.Repeat("-", width) + " |")
+		}
+	}
+	buf.WriteString("\n")
+
+	for _, row := range cells[1:] {
+		writeRow(row)
+	}
+
+	return buf.String(), nil
+}
+
+// TableCSV renders the table as comma-separated values, text styles are removed
+func TableCSV(table [][]string, tableOptions ...TableOption) (string, error) {
+	return tableDelimited(',', table, tableOptions)
+}
+
+// TableTSV renders the table as tab-separated values, text styles are removed
+func TableTSV(table [][]string, tableOptions ...TableOption) (string, error) {
+	return tableDelimited('\t', table, tableOptions)
+}
+
+func tableDelimited(delimiter rune, table [][]string, tableOptions []TableOption) (string, error) {
+	table, _, options, err := prepareTable(table, tableOptions)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Comma = delimiter
+
+	for _, row := range options.split(table).rows() {
+		record := make([]string, len(row))
+		for y, cell := range row {
+			record[y] = bunt.RemoveAllEscapeSequences(cell)
+		}
+
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return buf.String(), writer.Error()
+}
+
+// TableHTML renders the table as an HTML table, header rows are rendered in the
+// table head section and text styles are converted into inline styles
+func TableHTML(table [][]string, tableOptions ...TableOption) (string, error) {
+	table, _, options, err := prepareTable(table, tableOptions)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	var writeSection = func(section string, tag string, rows [][]string) {
+		if len(rows) == 0 {
+			return
+		}
+
+		fmt.Fprintf(&buf, "  <%s>\n", section)
+		for _, row := range rows {
+			buf.WriteString("    <tr>")
+			for y, cell := range row {
+				switch options.columnAlignment[y] {
+				case Right, Decimal:
+					fmt.Fprintf(&buf, `<%s style="text-align: right">`, tag)
+
+				case Center:
+					fmt.Fprintf(&buf, `<%s style="text-align: center">`, tag)
+
+				default:
+					fmt.Fprintf(&buf, "<%s>", tag)
+				}
+
+				buf.WriteString(htmlCell(cell))
+				fmt.Fprintf(&buf, "</%s>", tag)
+			}
+			buf.WriteString("</tr>\n")
+		}
+		fmt.Fprintf(&buf, "  </%s>\n", section)
+	}
+
+	sections := options.split(table)
+
+	buf.WriteString("<table>\n")
+	writeSection("thead", "th", sections.header)
+	writeSection("tbody", "td", sections.body)
+	writeSection("tfoot", "td", sections.footer)
+	buf.WriteString("</table>\n")
+
+	return buf.String(), nil
+}
+
+func markdownEscape(cell string) string {
+	return strings.NewReplacer(
+		"|", `\|`,
+		"\r\n", "<br>",
+		"\n", "<br>",
+	).Replace(bunt.RemoveAllEscapeSequences(cell))
+}
+
+// htmlCell converts the cell content into HTML, where the text styles of the
+// ANSI escape sequences are applied using spans with inline styles
+func htmlCell(cell string) string {
+	var (
+		buf     bytes.Buffer
+		style   textStyle
+		current string
+		pending strings.Builder
+	)
+
+	var flush = func() {
+		if pending.Len() == 0 {
+			return
+		}
+
+		content := strings.ReplaceAll(html.EscapeString(pending.String()), "\n", "<br>")
+		if len(current) > 0 {
+			fmt.Fprintf(&buf, `<span style="%s">%s</span>`, current, content)
+		} else {
+			buf.WriteString(content)
+		}
+
+		pending.Reset()
+	}
+
+	for len(cell) > 0 {
+		idx := strings.Index(cell, "\x1b[")
+		if idx < 0 {
+			idx = len(cell)
+		}
+
+		if idx > 0 {
+			if css := style.css(); css != current {
+				flush()
+				current = css
+			}
+
+			pending.WriteString(cell[:idx])
+		}
+
+		if cell = cell[idx:]; len(cell) == 0 {
+			break
+		}
+
+		// Escape sequences end with a byte in the range of @ to ~, only select
+		// graphic rendition sequences (ending with m) change the text style
+		end := strings.IndexFunc(cell[2:], func(r rune) bool { return r >= '@' && r <= '~' })
+		if end < 0 {
+			break
+		}
+
+		if cell[2+end] == 'm' {
+			style.apply(strings.Split(cell[2:2+end], ";"))
+		}
+
+		cell = cell[2+end+1:]
+	}
+
+	flush()
+	return buf.String()
+}
+
+// textStyle is the text style set by ANSI select graphic rendition escape
+// sequences, colors are stored as CSS hex colors
+type textStyle struct {
+	foreground string
+	background string
+	bold       bool
+	italic     bool
+	underline  bool
+}
+
+// ansiColors are the xterm default colors of the 16 standard terminal colors
+var ansiColors = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+func (s *textStyle) apply(params []string) {
+	for i := 0; i < len(params); i++ {
+		switch code, _ := strconv.Atoi(params[i]); {
+		case code == 0:
+			*s = textStyle{}
+
+		case code == 1:
+			s.bold = true
+
+		case code == 3:
+			s.italic = true
+
+		case code == 4:
+			s.underline = true
+
+		case code == 22:
+			s.bold = false
+
+		case code == 23:
+			s.italic = false
+
+		case code == 24:
+			s.underline = false
+
+		case code >= 30 && code <= 37:
+			s.foreground = ansiColors[code-30]
+
+		case code >= 90 && code <= 97:
+			s.foreground = ansiColors[code-90+8]
+
+		case code >= 40 && code <= 47:
+			s.background = ansiColors[code-40]
+
+		case code >= 100 && code <= 107:
+			s.background = ansiColors[code-100+8]
+
+		case code == 39:
+			s.foreground = ""
+
+		case code == 49:
+			s.background = ""
+
+		case code == 38 || code == 48:
+			color, consumed := extendedColor(params[i+1:])
+			if code == 38 {
+				s.foreground = color
+			} else {
+				s.background = color
+			}
+
+			i += consumed
+		}
+	}
+}
+
+// extendedColor returns the color of the parameters that follow an extended
+// color code, which are either 24-bit or 256 color parameters, and the number
+// of parameters that are part of the color
+func extendedColor(params []string) (string, int) {
+	var number = func(idx int) int {
+		value, _ := strconv.Atoi(params[idx])
+		return min(max(value, 0), 255)
+	}
+
+	switch {
+	case len(params) >= 4 && params[0] == "2":
+		return fmt.Sprintf("#%02x%02x%02x", number(1), number(2), number(3)), 4
+
+	case len(params) >= 2 && params[0] == "5":
+		switch color := number(1); {
+		case color < 16:
+			return ansiColors[color], 2
+
+		case color < 232:
+			levels := [6]int{0, 95, 135, 175, 215, 255}
+			color -= 16
+			return fmt.Sprintf("#%02x%02x%02x", levels[color/36], levels[color/6%6], levels[color%6]), 2
+
+		default:
+			gray := 8 + (color-232)*10
+			return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray), 2
+		}
+	}
+
+	return "", len(params)
+}
+
+// css returns the inline CSS style of the text style
+func (s textStyle) css() string {
+	var styles []string
+
+	if len(s.foreground) > 0 {
+		styles = append(styles, "color: "+s.foreground)
+	}
+
+	if len(s.background) > 0 {
+		styles = append(styles, "background-color: "+s.background)
+	}
+
+	if s.bold {
+		styles = append(styles, "font-weight: bold")
+	}
+
+	if s.italic {
+		styles = append(styles, "font-style: italic")
+	}
+
+	if s.underline {
+		styles = append(styles, "text-decoration: underline")
+	}
+
+	return strings.Join(styles, "; ")
+}
@@ -0,0 +1,124 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/bunt"
+	. "github.com/gonvenience/neat"
+)
+
+var _ = Describe("Table export", func() {
+	BeforeEach(func() {
+		SetColorSettings(ON, ON)
+	})
+
+	AfterEach(func() {
+		SetColorSettings(AUTO, AUTO)
+	})
+
+	var table = [][]string{
+		{"name", "count", "state"},
+		{"foo|bar", "1", Sprint("Green{ok}")},
+		{"baz", "42", "multi\nline"},
+	}
+
+	Context("Exporting tables to Markdown", func() {
+		It("should use the first row as header and the alignment in the delimiter row", func() {
+			result, err := TableMarkdown(table, AlignRight(1), AlignCenter(2))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEquivalentTo(`| name     | count | state         |
+| -------- | ----: | :-----------: |
+| foo\|bar | 1     | ok            |
+| baz      | 42    | multi<br>line |
+`))
+		})
+
+		It("should fail for invalid column indexes", func() {
+			_, err := TableMarkdown(table, AlignRight(3))
+			Expect(err).To(HaveOccurred())
+			Expect(err).To(BeAssignableToTypeOf(&ColumnIndexIsOutOfBoundsError{}))
+		})
+	})
+
+	Context("Exporting tables to CSV and TSV", func() {
+		It("should quote values where required", func() {
+			result, err := TableCSV([][]string{
+				{"name", "description"},
+				{"foo", "a, b and \"c\""},
+				{"bar", Sprint("Red{text}")},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEquivalentTo(`name,description
+foo,"a, b and ""c"""
+bar,text
+`))
+		})
+
+		It("should use tabs as delimiter and honor the row limit", func() {
+			result, err := TableTSV(table, HeaderRows(1), LimitRows(1))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEquivalentTo("name\tcount\tstate\nfoo|bar\t1\tok\n"))
+		})
+
+		It("should fail for empty tables", func() {
+			_, err := TableCSV([][]string{})
+			Expect(err).To(BeAssignableToTypeOf(&EmptyTableError{}))
+		})
+	})
+
+	Context("Exporting tables to HTML", func() {
+		It("should render header rows, alignment and inline styles", func() {
+			result, err := TableHTML([][]string{
+				{"name", "count"},
+				{"<foo>", Sprint("*Red{1}*")},
+			}, HeaderRows(1), AlignRight(1))
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEquivalentTo(`<table>
+  <thead>
+    <tr><th>name</th><th style="text-align: right">count</th></tr>
+  </thead>
+  <tbody>
+    <tr><td>&lt;foo&gt;</td><td style="text-align: right"><span style="color: #ff0000; font-weight: bold">1</span></td></tr>
+  </tbody>
+</table>
+`))
+		})
+
+		It("should convert standard, 256 and 24-bit colors of escape sequences", func() {
+			result, err := TableHTML([][]string{
+				{"\x1b[31mred\x1b[0m plain", "\x1b[38;5;196;4mcube\x1b[24m \x1b[48;2;1;2;3mtrue\x1b[49m"},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEquivalentTo(`<table>
+  <tbody>
+    <tr><td><span style="color: #cd0000">red</span> plain</td><td><span style="color: #ff0000; text-decoration: underline">cube</span><span style="color: #ff0000"> </span><span style="color: #ff0000; background-color: #010203">true</span></td></tr>
+  </tbody>
+</table>
+`))
+		})
+	})
+})
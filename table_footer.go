@@ -0,0 +1,176 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Aggregation defines how the values of a column are summarized in the
+// summary row of a table
+type Aggregation int
+
+// The aggregations are calculated over the numeric cell values of all rows
+// that pass the filters, including rows omitted due to the row limit. Count
+// counts the number of non-empty cells. All results are rounded to the largest
+// number of decimals of the values, averages to at least two decimals.
+const (
+	Sum Aggregation = iota + 1
+	Count
+	Min
+	Max
+	Average
+)
+
+// FooterRows marks the last rows of the table as footer rows, which are not
+// sorted, filtered or truncated and are separated from the other rows by a rule
+func FooterRows(rows int) TableOption {
+	return func(opts *options) {
+		opts.footerRows = rows
+	}
+}
+
+// Aggregate adds a summary row to the footer of the table, which shows the
+// result of the aggregation for the given columns (referenced by index)
+func Aggregate(aggregation Aggregation, cols ...int) TableOption {
+	return func(opts *options) {
+		opts.forEachColumn(cols, func(col int) {
+			opts.columnAggregation[col] = aggregation
+		})
+	}
+}
+
+// SummaryLabel sets the text shown in the first column of the summary row,
+// unless the first column is aggregated itself
+func SummaryLabel(label string) TableOption {
+	return func(opts *options) {
+		opts.summaryLabel = label
+	}
+}
+
+// summaryRow calculates the aggregations over the given rows, it returns nil
+// if there are no aggregations configured or no rows to aggregate
+func (opts options) summaryRow(rows [][]string) []string {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var summary = make([]string, len(opts.columnAggregation))
+	var aggregated bool
+
+	for y, aggregation := range opts.columnAggregation {
+		if aggregation == 0 {
+			continue
+		}
+
+		aggregated = true
+		summary[y] = aggregate(aggregation, rows, y)
+	}
+
+	if !aggregated {
+		return nil
+	}
+
+	if len(summary[0]) == 0 {
+		summary[0] = opts.summaryLabel
+	}
+
+	return summary
+}
+
+func aggregate(aggregation Aggregation, rows [][]string, col int) string {
+	var (
+		count    int
+		values   []float64
+		decimals int
+	)
+
+	for _, row := range rows {
+		cell := strings.TrimSpace(plainCell(row[col]))
+		if len(cell) == 0 {
+			continue
+		}
+
+		count++
+		if value, err := strconv.ParseFloat(cell, 64); err == nil {
+			values = append(values, value)
+			decimals = max(decimals, countDecimals(cell))
+		}
+	}
+
+	switch {
+	case aggregation == Count:
+		return strconv.Itoa(count)
+
+	case aggregation == Sum && len(values) == 0:
+		return "0"
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	var result float64
+	switch aggregation {
+	case Sum, Average:
+		for _, value := range values {
+			result += value
+		}
+
+		if aggregation == Average {
+			result /= float64(len(values))
+			decimals = max(decimals, 2)
+		}
+
+	case Min:
+		result = values[0]
+		for _, value := range values[1:] {
+			result = math.Min(result, value)
+		}
+
+	case Max:
+		result = values[0]
+		for _, value := range values[1:] {
+			result = math.Max(result, value)
+		}
+	}
+
+	// Rounding removes floating point artifacts like 0.30000000000000004
+	scale := math.Pow10(decimals)
+	return strconv.FormatFloat(math.Round(result*scale)/scale, 'f', -1, 64)
+}
+
+// countDecimals returns the number of digits after the decimal point
+func countDecimals(number string) int {
+	_, fraction, found := strings.Cut(number, ".")
+	if !found {
+		return 0
+	}
+
+	var count int
+	for count < len(fraction) && isASCIIDigit(fraction[count]) {
+		count++
+	}
+
+	return count
+}
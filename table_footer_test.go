@@ -0,0 +1,136 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/bunt"
+	. "github.com/gonvenience/neat"
+)
+
+var _ = Describe("Table footer rows", func() {
+	BeforeEach(func() {
+		SetColorSettings(OFF, OFF)
+	})
+
+	AfterEach(func() {
+		SetColorSettings(AUTO, AUTO)
+	})
+
+	var table = [][]string{
+		{"pod", "cpu", "memory"},
+		{"api", "250", "512"},
+		{"worker", "1500", "2048"},
+		{"cache", "100", "n/a"},
+	}
+
+	Context("Rendering footer rows", func() {
+		It("should separate the footer rows with a rule and not sort them", func() {
+			tableString, err := Table([][]string{
+				{"b", "2"},
+				{"a", "1"},
+				{"total", "3"},
+			}, FooterRows(1), SortBy(0, nil))
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`a     1
+b     2
+───────
+total 3
+`))
+		})
+	})
+
+	Context("Aggregating columns", func() {
+		It("should show the aggregations in a summary row", func() {
+			tableString, err := Table(table, HeaderRows(1), HeaderStyle(), AlignRight(1, 2), Aggregate(Sum, 1), Aggregate(Average, 2), SummaryLabel("total"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`pod     cpu memory
+──────────────────
+api     250    512
+worker 1500   2048
+cache   100    n/a
+──────────────────
+total  1850   1280
+`))
+		})
+
+		It("should support counting, minimum and maximum", func() {
+			tableString, err := Table(table[1:], Aggregate(Count, 0), Aggregate(Min, 1), Aggregate(Max, 2))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`api    250  512
+worker 1500 2048
+cache  100  n/a
+────────────────
+3      100  2048
+`))
+		})
+
+		It("should aggregate over all rows even if the rows are limited", func() {
+			tableString, err := Table(table, HeaderRows(1), HeaderStyle(), LimitRows(1), Aggregate(Sum, 1), SummaryLabel("total"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo("pod   cpu  memory\n" +
+				"─────────────────\n" +
+				"api   250  512\n" +
+				"[...]\n" +
+				"─────────────────\n" +
+				"total 1850\n"))
+		})
+
+		It("should round the results to the decimals of the values", func() {
+			tableString, err := Table([][]string{
+				{"0.1", "1", "1"},
+				{"0.2", "2", "2"},
+				{"0.25", "2", "1.5"},
+			}, Aggregate(Sum, 0), Aggregate(Average, 1, 2))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`0.1  1    1
+0.2  2    2
+0.25 2    1.5
+─────────────
+0.55 1.67 1.5
+`))
+		})
+
+		It("should not show a summary row if there are no rows to aggregate", func() {
+			tableString, err := Table([][]string{{"h", "v"}}, HeaderRows(1), Aggregate(Sum, 1), SummaryLabel("total"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo("h v\n───\n"))
+		})
+
+		It("should show zero as the sum of a column without numbers", func() {
+			tableString, err := Table([][]string{{"a", "n/a"}, {"b", ""}}, Aggregate(Sum, 1), SummaryLabel("total"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`a     n/a
+b
+─────────
+total 0
+`))
+		})
+
+		It("should fail for a column index out of bounds", func() {
+			_, err := Table(table, Aggregate(Sum, 5))
+			Expect(err).To(BeAssignableToTypeOf(&ColumnIndexIsOutOfBoundsError{}))
+		})
+	})
+})
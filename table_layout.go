@@ -0,0 +1,209 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/term"
+)
+
+const ellipsis = "…"
+
+// shrinkColumns reduces the column widths until the table fits into the target
+// width, columns with the lowest priority are shrunk first, and within the same
+// priority, the widest column is shrunk first
+func shrinkColumns(maxs []int, options options) error {
+	var target = options.targetWidth
+	if target <= 0 {
+		target = term.GetTerminalWidth()
+	}
+
+	var available = target - options.frameWidth() - (len(maxs)-1)*displayWidth(options.separator)
+
+	var total int
+	for _, max := range maxs {
+		total += max
+	}
+
+	for ; total > available; total-- {
+		candidate := -1
+		for y, max := range maxs {
+			if max <= options.columnMinWidth[y] || max <= 1 {
+				continue
+			}
+
+			if candidate < 0 ||
+				options.columnPriority[y] < options.columnPriority[candidate] ||
+				(options.columnPriority[y] == options.columnPriority[candidate] && max > maxs[candidate]) {
+				candidate = y
+			}
+		}
+
+		if candidate < 0 {
+			return &RowLengthExceedsDesiredWidthError{Required: target - available + total, Desired: target}
+		}
+
+		maxs[candidate]--
+	}
+
+	return nil
+}
+
+// cellWidth returns the width of the longest line of the cell content
+func cellWidth(cell string) int {
+	var max int
+	for _, line := range strings.Split(bunt.RemoveAllEscapeSequences(cell), "\n") {
+		if length := displayWidth(line); length > max {
+			max = length
+		}
+	}
+
+	return max
+}
+
+// splitLines splits multi-line cell content into its lines, text styles that
+// span multiple lines are applied to each individual line
+func splitLines(cell string) []string {
+	if !strings.Contains(cell, "\n") {
+		return []string{cell}
+	}
+
+	if bunt.PlainTextLength(cell) == utf8.RuneCountInString(cell) {
+		return strings.Split(cell, "\n")
+	}
+
+	text, err := bunt.ParseString(cell)
+	if err != nil {
+		return strings.Split(cell, "\n")
+	}
+
+	var (
+		lines []string
+		start int
+	)
+
+	for i, coloredRune := range *text {
+		if coloredRune.Symbol == '\n' {
+			lines = append(lines, (*text)[start:i].String())
+			start = i + 1
+		}
+	}
+
+	return append(lines, (*text)[start:].String())
+}
+
+// layoutCell splits the cell content into lines that fit into the given width
+func layoutCell(cell string, width int, overflow Overflow) []string {
+	var lines []string
+	for _, line := range splitLines(cell) {
+		if displayWidth(line) <= width {
+			lines = append(lines, line)
+			continue
+		}
+
+		switch overflow {
+		case Truncate:
+			plain := []rune(bunt.RemoveAllEscapeSequences(line))
+			lines = append(lines, bunt.Substring(line, 0, fittingRunes(plain, width-1))+ellipsis)
+
+		default:
+			for _, r := range wrapRanges([]rune(bunt.RemoveAllEscapeSequences(line)), width) {
+				lines = append(lines, bunt.Substring(line, r[0], r[1]))
+			}
+		}
+	}
+
+	return lines
+}
+
+// wrapRanges returns the start and end indexes of the lines when the text is
+// word-wrapped at the given width, words that are longer than the width are
+// split across lines
+func wrapRanges(text []rune, width int) [][2]int {
+	var (
+		ranges [][2]int
+		start  = 0
+	)
+
+	for start < len(text) {
+		// Skip leading whitespace of the line
+		for start < len(text) && text[start] == ' ' {
+			start++
+		}
+
+		if start >= len(text) {
+			break
+		}
+
+		end := start + fittingRunes(text[start:], width)
+		if end >= len(text) {
+			ranges = append(ranges, [2]int{start, len(text)})
+			break
+		}
+
+		// Make sure to make progress, even if a character is wider than the line
+		if end == start {
+			ranges = append(ranges, [2]int{start, start + 1})
+			start++
+			continue
+		}
+
+		// Break at the last space inside the line, if there is one
+		for i := end; i > start; i-- {
+			if text[i] == ' ' {
+				end = i
+				break
+			}
+		}
+
+		ranges = append(ranges, [2]int{start, trimRight(text, start, end)})
+		start = end
+	}
+
+	return ranges
+}
+
+// fittingRunes returns the number of runes that fit into the given width
+func fittingRunes(text []rune, width int) int {
+	var used int
+	for i, r := range text {
+		if i > 0 && text[i-1] == zeroWidthJoiner {
+			continue
+		}
+
+		if used += runeWidth(r); used > width {
+			return i
+		}
+	}
+
+	return len(text)
+}
+
+func trimRight(text []rune, start int, end int) int {
+	for end > start && text[end-1] == ' ' {
+		end--
+	}
+
+	return end
+}
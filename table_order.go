@@ -0,0 +1,243 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+)
+
+// CellComparator compares two cell values and returns a negative number if a
+// is less than b, a positive number if a is greater than b, and zero otherwise.
+// The cell values are passed without text styles.
+type CellComparator func(a, b string) int
+
+// GroupMode defines how groups of rows are visually separated
+type GroupMode int
+
+// Groups of rows can be introduced by a group header line showing the value of
+// the group column, or separated by an empty line. In tables with a border,
+// groups are separated by a rule, or an empty row if there are no rules.
+const (
+	GroupHeaders GroupMode = iota
+	GroupSeparators
+)
+
+type sortKey struct {
+	col     int
+	compare CellComparator
+}
+
+// SortBy sorts the table rows by the given column (referenced by index) using
+// the provided comparator, or string order if it is nil. Using the option more
+// than once sorts by multiple columns, where the first one has precedence.
+// Header rows are not sorted.
+func SortBy(col int, compare CellComparator) TableOption {
+	return func(opts *options) {
+		if compare == nil {
+			compare = CompareStrings
+		}
+
+		opts.forEachColumn([]int{col}, func(col int) {
+			opts.sortKeys = append(opts.sortKeys, sortKey{col, compare})
+		})
+	}
+}
+
+// FilterRows only renders the rows for which the predicate returns true,
+// header rows are not filtered
+func FilterRows(predicate func(row []string) bool) TableOption {
+	return func(opts *options) {
+		opts.filters = append(opts.filters, predicate)
+	}
+}
+
+// GroupBy groups rows with the same value in the given column (referenced by
+// index) together, groups are ordered by the first occurrence of their value
+func GroupBy(col int, mode GroupMode) TableOption {
+	return func(opts *options) {
+		opts.forEachColumn([]int{col}, func(col int) {
+			opts.groupColumn = col
+			opts.groupMode = mode
+		})
+	}
+}
+
+// CompareStrings compares cell values lexicographically
+func CompareStrings(a, b string) int {
+	return strings.Compare(a, b)
+}
+
+// CompareNatural compares cell values in natural order, where sequences of
+// digits are compared by their numeric value, so that `item2` comes before
+// `item10`
+func CompareNatural(a, b string) int {
+	for len(a) > 0 && len(b) > 0 {
+		chunkA, chunkB := naturalChunk(a), naturalChunk(b)
+		a, b = a[len(chunkA):], b[len(chunkB):]
+
+		if isDigit(chunkA) && isDigit(chunkB) {
+			numA, numB := strings.TrimLeft(chunkA, "0"), strings.TrimLeft(chunkB, "0")
+			if len(numA) != len(numB) {
+				return len(numA) - len(numB)
+			}
+
+			if result := strings.Compare(numA, numB); result != 0 {
+				return result
+			}
+
+			continue
+		}
+
+		if result := strings.Compare(chunkA, chunkB); result != 0 {
+			return result
+		}
+	}
+
+	return len(a) - len(b)
+}
+
+// CompareNumeric compares cell values by their numeric value, values that are
+// not numbers are sorted after all numbers in string order
+func CompareNumeric(a, b string) int {
+	numA, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	numB, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+
+	case errA != nil:
+		return 1
+
+	case errB != nil:
+		return -1
+
+	case numA < numB:
+		return -1
+
+	case numA > numB:
+		return 1
+
+	default:
+		return 0
+	}
+}
+
+// Descending reverses the order of the given comparator
+func Descending(compare CellComparator) CellComparator {
+	return func(a, b string) int {
+		return compare(b, a)
+	}
+}
+
+// arrange filters, sorts and groups the table rows in the given range, it
+// returns the indexes of the resulting rows, the table is not modified
+func (opts options) arrange(table [][]string, from int, to int) []int {
+	var result = make([]int, 0, to-from)
+	for i := from; i < to; i++ {
+		if opts.matchesFilters(table[i]) {
+			result = append(result, i)
+		}
+	}
+
+	if len(opts.sortKeys) > 0 {
+		slices.SortStableFunc(result, func(a, b int) int {
+			for _, key := range opts.sortKeys {
+				if result := key.compare(plainCell(table[a][key.col]), plainCell(table[b][key.col])); result != 0 {
+					return result
+				}
+			}
+
+			return 0
+		})
+	}
+
+	if opts.groupColumn >= 0 {
+		var (
+			order  []string
+			groups = map[string][]int{}
+		)
+
+		for _, idx := range result {
+			value := plainCell(table[idx][opts.groupColumn])
+			if _, ok := groups[value]; !ok {
+				order = append(order, value)
+			}
+
+			groups[value] = append(groups[value], idx)
+		}
+
+		result = result[:0]
+		for _, value := range order {
+			result = append(result, groups[value]...)
+		}
+	}
+
+	return result
+}
+
+func (opts options) matchesFilters(row []string) bool {
+	for _, predicate := range opts.filters {
+		if !predicate(row) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// startsGroup returns whether the row at the given index starts a new group
+func (opts options) startsGroup(body [][]string, idx int) bool {
+	if opts.groupColumn < 0 {
+		return false
+	}
+
+	return idx == 0 || plainCell(body[idx][opts.groupColumn]) != plainCell(body[idx-1][opts.groupColumn])
+}
+
+func plainCell(cell string) string {
+	return bunt.RemoveAllEscapeSequences(cell)
+}
+
+// naturalChunk returns the leading sequence of either ASCII digits or other
+// characters, which is never empty for non-empty text
+func naturalChunk(text string) string {
+	digit := isASCIIDigit(text[0])
+	for i := 1; i < len(text); i++ {
+		if isASCIIDigit(text[i]) != digit {
+			return text[:i]
+		}
+	}
+
+	return text
+}
+
+func isDigit(text string) bool {
+	return len(text) > 0 && isASCIIDigit(text[0])
+}
+
+func isASCIIDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
@@ -0,0 +1,170 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/bunt"
+	. "github.com/gonvenience/neat"
+)
+
+var _ = Describe("Table sorting, filtering and grouping", func() {
+	BeforeEach(func() {
+		SetColorSettings(OFF, OFF)
+	})
+
+	AfterEach(func() {
+		SetColorSettings(AUTO, AUTO)
+	})
+
+	var table = [][]string{
+		{"name", "zone", "size"},
+		{"node10", "b", "8"},
+		{"node2", "a", "16"},
+		{"node1", "b", "n/a"},
+		{"node3", "a", "4"},
+	}
+
+	Context("Comparing cell values", func() {
+		It("should compare values in natural order", func() {
+			Expect(CompareNatural("item2", "item10")).To(BeNumerically("<", 0))
+			Expect(CompareNatural("item10", "item2")).To(BeNumerically(">", 0))
+			Expect(CompareNatural("item02", "item2")).To(BeZero())
+			Expect(CompareNatural("a", "ab")).To(BeNumerically("<", 0))
+			Expect(CompareNatural("١٢", "١٣")).To(BeNumerically("<", 0))
+			Expect(CompareNatural("v１0", "v１2")).To(BeNumerically("<", 0))
+			Expect(CompareNatural("日本9", "日本10")).To(BeNumerically("<", 0))
+		})
+
+		It("should compare numbers by value and sort other values last", func() {
+			Expect(CompareNumeric("9", "10")).To(BeNumerically("<", 0))
+			Expect(CompareNumeric("1.5", "1.5")).To(BeZero())
+			Expect(CompareNumeric("n/a", "10")).To(BeNumerically(">", 0))
+			Expect(CompareNumeric("10", "n/a")).To(BeNumerically("<", 0))
+		})
+	})
+
+	Context("Sorting rows", func() {
+		It("should sort by a column while keeping the header rows in place", func() {
+			tableString, err := Table(table, HeaderRows(1), HeaderStyle(), SortBy(0, CompareNatural))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`name   zone size
+────────────────
+node1  b    n/a
+node2  a    16
+node3  a    4
+node10 b    8
+`))
+		})
+
+		It("should sort by multiple columns with the first one taking precedence", func() {
+			tableString, err := Table(table, HeaderRows(1), HeaderStyle(), SortBy(1, nil), SortBy(2, Descending(CompareNumeric)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`name   zone size
+────────────────
+node2  a    16
+node3  a    4
+node1  b    n/a
+node10 b    8
+`))
+		})
+
+		It("should not modify the order of the input table", func() {
+			_, err := Table(table, HeaderRows(1), SortBy(0, nil))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(table[1][0]).To(Equal("node10"))
+		})
+
+		It("should fail for a column index out of bounds", func() {
+			_, err := Table(table, SortBy(3, nil))
+			Expect(err).To(BeAssignableToTypeOf(&ColumnIndexIsOutOfBoundsError{}))
+		})
+	})
+
+	Context("Filtering rows", func() {
+		It("should only render rows matching the predicate before applying the row limit", func() {
+			tableString, err := Table(table, HeaderRows(1), HeaderStyle(), LimitRows(1), FilterRows(func(row []string) bool {
+				return row[1] == "a"
+			}))
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`name  zone size
+───────────────
+node2 a    16
+[...]
+`))
+		})
+	})
+
+	Context("Grouping rows", func() {
+		It("should introduce each group with a group header", func() {
+			tableString, err := Table(table, HeaderRows(1), HeaderStyle(), GroupBy(1, GroupHeaders), SortBy(0, CompareNatural), TableBorder(ASCIIBorder))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`+--------+------+------+
+| name   | zone | size |
++--------+------+------+
+| b                    |
+| node1  | b    | n/a  |
+| node10 | b    | 8    |
++--------+------+------+
+| a                    |
+| node2  | a    | 16   |
+| node3  | a    | 4    |
++--------+------+------+
+`))
+		})
+
+		It("should separate groups with an empty line", func() {
+			tableString, err := Table(table[1:], GroupBy(1, GroupSeparators), FilterRows(func(row []string) bool {
+				return !strings.HasPrefix(row[2], "n")
+			}))
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`node10 b 8
+
+node2  a 16
+node3  a 4
+`))
+		})
+
+		It("should separate groups with a rule in tables with a border", func() {
+			tableString, err := Table([][]string{{"a", "1"}, {"b", "2"}}, GroupBy(0, GroupSeparators), TableBorder(SingleLineBorder))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`┌───┬───┐
+│ a │ 1 │
+├───┼───┤
+│ b │ 2 │
+└───┴───┘
+`))
+
+			tableString, err = Table([][]string{{"a", "1"}, {"b", "2"}}, GroupBy(0, GroupSeparators), TableBorder(BorderStyle{Left: "|", Vertical: "|", Right: "|"}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`| a | 1 |
+|   |   |
+| b | 2 |
+`))
+		})
+	})
+})
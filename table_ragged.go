@@ -0,0 +1,86 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+// LongRowMode defines how rows with more cells than the first row are handled
+// when the table is rendered in lenient mode
+type LongRowMode int
+
+// Rows with additional cells are either truncated to the number of columns of
+// the first row, or the additional cells overflow into new columns, which
+// all other rows are padded to.
+const (
+	TruncateLongRows LongRowMode = iota
+	OverflowLongRows
+)
+
+// LenientRows renders tables with rows of different length instead of failing
+// with an ImbalancedTableError: short rows are padded with the filler cell,
+// which can contain text styles (for example a dimmed dash), and long rows are
+// handled based on the mode. Table writers always truncate long rows, since
+// their columns are fixed once the first row is rendered.
+func LenientRows(filler string, mode LongRowMode) TableOption {
+	return func(opts *options) {
+		opts.lenientRows = true
+		opts.lenientFiller = filler
+		opts.longRowMode = mode
+	}
+}
+
+// balanceRows pads or truncates all rows to the same number of columns, rows
+// that already have the right length are kept as-is
+func (opts options) balanceRows(table [][]string) [][]string {
+	if len(table) == 0 {
+		return table
+	}
+
+	cols := len(table[0])
+	if opts.longRowMode == OverflowLongRows {
+		for _, row := range table {
+			cols = max(cols, len(row))
+		}
+	}
+
+	result := make([][]string, len(table))
+	for i, row := range table {
+		result[i] = opts.balanceRow(row, cols)
+	}
+
+	return result
+}
+
+func (opts options) balanceRow(row []string, cols int) []string {
+	switch {
+	case len(row) > cols:
+		return row[:cols]
+
+	case len(row) < cols:
+		padded := make([]string, cols)
+		copy(padded, row)
+		for i := len(row); i < cols; i++ {
+			padded[i] = opts.lenientFiller
+		}
+
+		return padded
+	}
+
+	return row
+}
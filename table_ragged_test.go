@@ -0,0 +1,113 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/bunt"
+	. "github.com/gonvenience/neat"
+)
+
+var _ = Describe("Lenient table rows", func() {
+	BeforeEach(func() {
+		SetColorSettings(OFF, OFF)
+	})
+
+	AfterEach(func() {
+		SetColorSettings(AUTO, AUTO)
+	})
+
+	var table = [][]string{
+		{"pod", "cpu", "memory"},
+		{"api", "250"},
+		{"worker", "1500", "2048", "restarted"},
+	}
+
+	Context("Rendering tables with rows of different length", func() {
+		It("should fail without lenient mode", func() {
+			_, err := Table(table)
+			Expect(err).To(MatchError(&ImbalancedTableError{Row: 1, Expected: 3, Actual: 2}))
+		})
+
+		It("should pad short rows and truncate long rows", func() {
+			tableString, err := Table(table, LenientRows("-", TruncateLongRows))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`pod    cpu  memory
+api    250  -
+worker 1500 2048
+`))
+		})
+
+		It("should add columns for the cells of long rows", func() {
+			tableString, err := Table(table, LenientRows("-", OverflowLongRows))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`pod    cpu  memory -
+api    250  -      -
+worker 1500 2048   restarted
+`))
+		})
+
+		It("should use the styled filler cell", func() {
+			SetColorSettings(ON, ON)
+
+			tableString, err := Table(table, LenientRows(Sprint("DimGray{-}"), TruncateLongRows))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(ContainSubstring(Sprint("DimGray{-}")))
+			Expect(RemoveAllEscapeSequences(tableString)).To(BeEquivalentTo(`pod    cpu  memory
+api    250  -
+worker 1500 2048
+`))
+		})
+
+		It("should support lenient mode in the table builder", func() {
+			tableString, err := NewTableBuilder().
+				AddColumn("name", Auto, nil).
+				AddColumn("size", Auto, nil).
+				AddRow("foo").
+				AddRow("bar", 42).
+				Render(LenientRows("-", TruncateLongRows))
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`name size
+─────────
+foo     -
+bar    42
+`))
+		})
+
+		It("should support lenient mode in the table writer", func() {
+			var buf bytes.Buffer
+			w := NewTableWriter(&buf, []string{"name", "status"}, LenientRows("-", OverflowLongRows))
+			Expect(w.WriteRow("api")).To(Succeed())
+			Expect(w.WriteRow("db", "ok", "primary")).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+			Expect(buf.String()).To(Equal(`name status
+───────────
+api  -
+db   ok
+`))
+		})
+	})
+})
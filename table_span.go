@@ -0,0 +1,383 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"bytes"
+	"strings"
+)
+
+// cellSpan describes a cell that is merged with its neighbouring cells
+type cellSpan struct {
+	row, col   int
+	rows, cols int
+}
+
+// rowLayout describes how the cells of a rendered row are merged
+type rowLayout struct {
+	// spans is the number of columns each cell spans, it is zero for cells that
+	// are merged into a cell to their left
+	spans []int
+
+	// covered marks the cells that are merged into a cell of a row above
+	covered []bool
+}
+
+// ruleKind defines the position of a horizontal rule in the table
+type ruleKind int
+
+const (
+	topRule ruleKind = iota
+	middleRule
+	bottomRule
+)
+
+// SpanColumns merges the cell at the given row and column (both referenced by
+// index) with the cells to its right, so that it spans the given number of
+// columns, the content of the merged cells is not rendered
+func SpanColumns(row int, col int, cols int) TableOption {
+	return func(opts *options) {
+		opts.forEachColumn([]int{col, col + max(cols, 1) - 1}, func(int) {})
+		opts.cellSpans = append(opts.cellSpans, cellSpan{row: row, col: col, rows: 1, cols: max(cols, 1)})
+	}
+}
+
+// SpanRows merges the cell at the given row and column (both referenced by
+// index) with the cells below it, so that it spans the given number of rows,
+// the content of the merged cells is not rendered
+func SpanRows(row int, col int, rows int) TableOption {
+	return func(opts *options) {
+		opts.forEachColumn([]int{col}, func(int) {})
+		opts.cellSpans = append(opts.cellSpans, cellSpan{row: row, col: col, rows: max(rows, 1), cols: 1})
+	}
+}
+
+// indexCellSpans associates the merged cells with the rows of the table, so
+// that they can be looked up by the row index after rows were sorted or
+// filtered, merged cells must not overlap
+func (opts *options) indexCellSpans(table [][]string) {
+	opts.rowSpans = map[int][]cellSpan{}
+	for _, span := range opts.cellSpans {
+		if span.row < 0 || span.row >= len(table) {
+			opts.errors = append(opts.errors, &RowIndexIsOutOfBoundsError{span.row})
+			continue
+		}
+
+		// Merging a cell both horizontally and vertically is done by using
+		// both options for the same cell
+		for i, existing := range opts.rowSpans[span.row] {
+			if existing.col == span.col {
+				span.rows = max(span.rows, existing.rows)
+				span.cols = max(span.cols, existing.cols)
+				opts.rowSpans[span.row] = append(opts.rowSpans[span.row][:i], opts.rowSpans[span.row][i+1:]...)
+				break
+			}
+		}
+
+		opts.rowSpans[span.row] = append(opts.rowSpans[span.row], span)
+	}
+
+	var spans []cellSpan
+	for row := range table {
+		spans = append(spans, opts.rowSpans[row]...)
+	}
+
+	for i, span := range spans {
+		for _, other := range spans[:i] {
+			if span.overlaps(other) {
+				opts.errors = append(opts.errors, &OverlappingCellSpansError{RowIdx: span.row, ColumnIdx: span.col})
+				break
+			}
+		}
+	}
+}
+
+// overlaps returns whether both merged cells share at least one cell
+func (s cellSpan) overlaps(other cellSpan) bool {
+	return s.row < other.row+other.rows && other.row < s.row+s.rows &&
+		s.col < other.col+other.cols && other.col < s.col+s.cols
+}
+
+// layouts determines how the cells of the given consecutive rows (referenced by
+// their index in the input table) are merged
+func (opts options) layouts(rows []int) []rowLayout {
+	var (
+		cols      = len(opts.columnAlignment)
+		result    = make([]rowLayout, len(rows))
+		remaining = make([]int, cols) // rows still covered by a merged cell
+		blocks    = make([]int, cols) // columns spanned by a merged cell
+	)
+
+	for i, rowIdx := range rows {
+		layout := plainLayout(cols)
+
+		for y := range remaining {
+			if remaining[y] > 0 {
+				remaining[y]--
+				layout.covered[y] = true
+			}
+		}
+
+		for y := range blocks {
+			if layout.covered[y] && blocks[y] > 0 {
+				layout.setSpan(y, blocks[y])
+			}
+
+			if remaining[y] == 0 {
+				blocks[y] = 0
+			}
+		}
+
+		for _, span := range opts.rowSpans[rowIdx] {
+			if layout.covered[span.col] {
+				continue
+			}
+
+			layout.setSpan(span.col, span.cols)
+			if span.rows > 1 {
+				blocks[span.col] = span.cols
+				for y := span.col; y < span.col+span.cols; y++ {
+					remaining[y] = span.rows - 1
+				}
+			}
+		}
+
+		result[i] = layout
+	}
+
+	return result
+}
+
+// plainLayout returns the layout of a row without merged cells
+func plainLayout(cols int) rowLayout {
+	var layout = rowLayout{
+		spans:   make([]int, cols),
+		covered: make([]bool, cols),
+	}
+
+	for y := range layout.spans {
+		layout.spans[y] = 1
+	}
+
+	return layout
+}
+
+// mergedLayout returns the layout of a row that consists of one single cell
+func mergedLayout(cols int) rowLayout {
+	var layout = plainLayout(cols)
+	layout.setSpan(0, cols)
+	return layout
+}
+
+func (l rowLayout) setSpan(col int, cols int) {
+	l.spans[col] = cols
+	for y := col + 1; y < col+cols && y < len(l.spans); y++ {
+		l.spans[y] = 0
+	}
+}
+
+// crosses returns whether a merged cell crosses the separator to the right of
+// the given column
+func (l rowLayout) crosses(col int) bool {
+	for y := col; y >= 0; y-- {
+		if l.spans[y] > 0 {
+			return y+l.spans[y]-1 > col
+		}
+	}
+
+	return false
+}
+
+// lookupColumnWidths calculates the column widths required by the rows, where
+// the space required by merged cells is distributed across their columns
+func lookupColumnWidths(rows [][]string, layouts []rowLayout, maxs []int, options options) {
+	for i, row := range rows {
+		for y, cell := range row {
+			if layouts[i].spans[y] == 1 && !layouts[i].covered[y] {
+				maxs[y] = max(maxs[y], cellWidth(cell))
+			}
+		}
+	}
+
+	for i, row := range rows {
+		for y, cell := range row {
+			span := layouts[i].spans[y]
+			if span <= 1 || layouts[i].covered[y] {
+				continue
+			}
+
+			if missing := cellWidth(cell) - spanWidth(maxs, y, span, options); missing > 0 {
+				for x := 0; x < span; x++ {
+					maxs[y+x] += missing / span
+					if x < missing%span {
+						maxs[y+x]++
+					}
+				}
+			}
+		}
+	}
+}
+
+// spanWidth returns the width of a cell that spans the given columns
+func spanWidth(maxs []int, col int, cols int, options options) int {
+	var width = (cols - 1) * displayWidth(options.separator)
+	for _, max := range maxs[col : col+cols] {
+		width += max
+	}
+
+	return width
+}
+
+// renderRule creates a horizontal line between the given rows, which matches
+// the column layout of both rows, so that junctions are only drawn where
+// there is a column separator in at least one of the rows
+func renderRule(maxs []int, options options, kind ruleKind, above rowLayout, below rowLayout) string {
+	var (
+		border  = options.border
+		fill    = [...]string{border.Top, border.Middle, border.Bottom}[kind]
+		frame   = len(border.Left) > 0 || len(border.Right) > 0
+		padding = 0
+	)
+
+	if len(fill) == 0 {
+		fill = "─"
+	}
+
+	// Column separators of framed tables are padded with a space on each side
+	if len(border.Vertical) > 0 {
+		padding = 2
+	}
+
+	var drawn = func(col int) bool {
+		return kind != middleRule || col < 0 || col >= len(below.covered) || !below.covered[col]
+	}
+
+	var widths = make([]int, len(maxs))
+	for y, max := range maxs {
+		widths[y] = max + padding
+	}
+
+	// Without a frame, the first and last segment do not have padding
+	if padding > 0 && len(border.Left) == 0 && len(widths) > 0 {
+		widths[0]--
+	}
+
+	if padding > 0 && len(border.Right) == 0 && len(widths) > 0 {
+		widths[len(widths)-1]--
+	}
+
+	var segments = make([]string, len(maxs))
+	for y, width := range widths {
+		if drawn(y) {
+			segments[y] = strings.Repeat(fill, width)
+		} else {
+			segments[y] = strings.Repeat(" ", width)
+		}
+	}
+
+	var buf bytes.Buffer
+	if len(border.Left) > 0 {
+		buf.WriteString(border.junction(fill, false, drawn(0), frame && kind != topRule, frame && kind != bottomRule))
+	}
+
+	// Tables without columns only consist of the padding inside the frame
+	if len(segments) == 0 {
+		if len(border.Left) > 0 {
+			buf.WriteString(fill)
+		}
+
+		if len(border.Right) > 0 {
+			buf.WriteString(fill)
+		}
+	}
+
+	for y, segment := range segments {
+		buf.WriteString(segment)
+		if y == len(segments)-1 {
+			break
+		}
+
+		junction := border.junction(fill, drawn(y), drawn(y+1),
+			kind != topRule && !above.crosses(y),
+			kind != bottomRule && !below.crosses(y))
+
+		if len(junction) == 0 {
+			width := max(0, displayWidth(options.separator)-padding)
+			if drawn(y) || drawn(y+1) {
+				junction = strings.Repeat(fill, width)
+			} else {
+				junction = strings.Repeat(" ", width)
+			}
+		}
+
+		buf.WriteString(junction)
+	}
+
+	if len(border.Right) > 0 {
+		buf.WriteString(border.junction(fill, drawn(len(maxs)-1), false, frame && kind != topRule, frame && kind != bottomRule))
+	}
+
+	return buf.String()
+}
+
+// junction returns the border character that connects the lines to the left,
+// right, top and bottom of it
+func (b BorderStyle) junction(fill string, left, right, up, down bool) string {
+	switch {
+	case left && right && up && down:
+		return b.MiddleJunction
+
+	case left && right && down:
+		return b.TopJunction
+
+	case left && right && up:
+		return b.BottomJunction
+
+	case left && right:
+		return fill
+
+	case right && up && down:
+		return b.MiddleLeft
+
+	case left && up && down:
+		return b.MiddleRight
+
+	case right && down:
+		return b.TopLeft
+
+	case right && up:
+		return b.BottomLeft
+
+	case left && down:
+		return b.TopRight
+
+	case left && up:
+		return b.BottomRight
+
+	case left || right:
+		return fill
+
+	case up || down:
+		return b.Vertical
+	}
+
+	return " "
+}
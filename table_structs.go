@@ -0,0 +1,242 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+type structColumn struct {
+	path      string
+	index     []int
+	header    string
+	alignment Alignment
+	format    string
+}
+
+// TableFromStructs renders a table based on a slice of structs, where each
+// exported field is a column. Fields of nested structs are columns as well,
+// which are referenced by their dotted path (for example `Metadata.Name`) to
+// select columns using Columns.
+//
+// The `neat` struct tag can be used to configure the column, for example
+// `neat:"Name,align=right,format=%.2f"` sets the header, alignment, and the
+// format string to be used, `neat:"-"` omits the field. Fields that refer back
+// to a struct type that is already expanded (for example a parent pointer) are
+// omitted as well.
+func TableFromStructs(slice interface{}, tableOptions ...TableOption) (string, error) {
+	value := reflect.ValueOf(slice)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return "", &UnsupportedTableInputError{Type: fmt.Sprintf("%T", slice)}
+	}
+
+	elemType := value.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	if elemType.Kind() != reflect.Struct {
+		return "", &UnsupportedTableInputError{Type: fmt.Sprintf("%T", slice)}
+	}
+
+	columns, err := lookupStructColumns(elemType, map[reflect.Type]bool{elemType: true}, nil, "", "")
+	if err != nil {
+		return "", err
+	}
+
+	var selection = peekOptions(tableOptions)
+	if len(selection.columnPaths) > 0 {
+		if columns, err = selectStructColumns(columns, selection.columnPaths); err != nil {
+			return "", err
+		}
+	}
+
+	builder := NewTableBuilder()
+	for _, column := range columns {
+		builder.AddColumn(column.header, column.alignment, column.formatter())
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		values := make([]interface{}, len(columns))
+		for j, column := range columns {
+			values[j] = column.value(value.Index(i))
+		}
+
+		builder.AddRow(values...)
+	}
+
+	return builder.Render(tableOptions...)
+}
+
+// lookupStructColumns returns the columns of the struct type, the visiting set
+// contains the struct types that are currently expanded
+func lookupStructColumns(structType reflect.Type, visiting map[reflect.Type]bool, index []int, pathPrefix string, headerPrefix string) ([]structColumn, error) {
+	var columns []structColumn
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("neat")
+		if tag == "-" {
+			continue
+		}
+
+		column := structColumn{
+			path:      pathPrefix + field.Name,
+			index:     append(append([]int{}, index...), i),
+			header:    headerPrefix + field.Name,
+			alignment: Auto,
+		}
+
+		for idx, part := range strings.Split(tag, ",") {
+			switch key, value, _ := strings.Cut(part, "="); {
+			case idx == 0:
+				if len(part) > 0 {
+					column.header = headerPrefix + part
+				}
+
+			case key == "align":
+				alignment, ok := map[string]Alignment{"left": Left, "right": Right, "center": Center, "auto": Auto, "decimal": Decimal}[value]
+				if !ok {
+					return nil, &InvalidStructTagError{Field: column.path, Tag: tag}
+				}
+
+				column.alignment = alignment
+
+			case key == "format":
+				column.format = value
+
+			default:
+				return nil, &InvalidStructTagError{Field: column.path, Tag: tag}
+			}
+		}
+
+		if fieldType := field.Type; isNestedStruct(fieldType) {
+			// Fields that refer back to a type that is currently expanded (like a
+			// parent pointer) are omitted, since there is no end to the columns
+			nestedType := derefType(fieldType)
+			if visiting[nestedType] {
+				continue
+			}
+
+			visiting[nestedType] = true
+			nested, err := lookupStructColumns(nestedType, visiting, column.index, column.path+".", column.header+".")
+			delete(visiting, nestedType)
+			if err != nil {
+				return nil, err
+			}
+
+			columns = append(columns, nested...)
+			continue
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+func selectStructColumns(columns []structColumn, paths []string) ([]structColumn, error) {
+	var result = make([]structColumn, len(paths))
+	for i, path := range paths {
+		var found bool
+		for _, column := range columns {
+			if column.path == path {
+				result[i], found = column, true
+				break
+			}
+		}
+
+		if !found {
+			return nil, &UnknownColumnError{Column: path}
+		}
+	}
+
+	return result, nil
+}
+
+func (c structColumn) value(value reflect.Value) interface{} {
+	for _, i := range c.index {
+		for value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return nil
+			}
+
+			value = value.Elem()
+		}
+
+		value = value.Field(i)
+	}
+
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	return value.Interface()
+}
+
+func (c structColumn) formatter() CellFormatter {
+	if len(c.format) == 0 {
+		return FormatValue
+	}
+
+	return func(value interface{}) string {
+		if value == nil {
+			return ""
+		}
+
+		return fmt.Sprintf(c.format, value)
+	}
+}
+
+// isNestedStruct checks whether the type is a struct, which fields should be
+// used as separate columns, this excludes timestamps and types with their own
+// string representation
+func isNestedStruct(t reflect.Type) bool {
+	t = derefType(t)
+	return t.Kind() == reflect.Struct &&
+		t != timeType &&
+		!t.Implements(stringerType) &&
+		!reflect.PointerTo(t).Implements(stringerType)
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}
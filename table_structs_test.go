@@ -0,0 +1,126 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/bunt"
+	. "github.com/gonvenience/neat"
+)
+
+type metadata struct {
+	Name      string
+	Namespace string `neat:"NS"`
+}
+
+type resource struct {
+	Metadata metadata
+	Replicas int     `neat:"Replicas"`
+	CPU      float64 `neat:"CPU,align=left,format=%.2f"`
+	Owner    *metadata
+	internal string
+	Secret   string `neat:"-"`
+}
+
+var _ = Describe("Table from structs", func() {
+	BeforeEach(func() {
+		SetColorSettings(OFF, OFF)
+	})
+
+	AfterEach(func() {
+		SetColorSettings(AUTO, AUTO)
+	})
+
+	var input = []resource{
+		{Metadata: metadata{Name: "api", Namespace: "default"}, Replicas: 3, CPU: 0.5, Owner: &metadata{Name: "team-a"}},
+		{Metadata: metadata{Name: "worker", Namespace: "jobs"}, Replicas: 12, CPU: 1.25},
+	}
+
+	Context("Rendering a slice of structs as a table", func() {
+		It("should use all exported fields as columns", func() {
+			tableString, err := TableFromStructs(input)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo("" +
+				"Metadata.Name Metadata.NS Replicas CPU  Owner.Name Owner.NS\n" +
+				"───────────────────────────────────────────────────────────\n" +
+				"api           default            3 0.50 team-a\n" +
+				"worker        jobs              12 1.25\n"))
+		})
+
+		It("should select columns by their dotted path", func() {
+			tableString, err := TableFromStructs(&input, Columns("Metadata.Name", "Replicas"))
+			Expect(err).To(MatchError(&UnsupportedTableInputError{Type: "*[]neat_test.resource"}))
+			Expect(tableString).To(BeEmpty())
+
+			tableString, err = TableFromStructs(input, Columns("Metadata.Name", "Replicas"), VertialBarSeparator())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`Metadata.Name │ Replicas
+────────────────────────
+api           │        3
+worker        │       12
+`))
+		})
+
+		It("should fail for unknown columns", func() {
+			_, err := TableFromStructs(input, Columns("Metadata.Labels"))
+			Expect(err).To(MatchError(&UnknownColumnError{Column: "Metadata.Labels"}))
+		})
+
+		It("should fail for invalid struct tags", func() {
+			type invalid struct {
+				Name string `neat:"Name,align=diagonal"`
+			}
+
+			_, err := TableFromStructs([]invalid{{Name: "foo"}})
+			Expect(err).To(MatchError(&InvalidStructTagError{Field: "Name", Tag: "Name,align=diagonal"}))
+		})
+
+		It("should omit fields that refer back to a type that is already expanded", func() {
+			type node struct {
+				Name   string
+				Parent *node
+			}
+
+			type tree struct {
+				Root  node
+				Other *tree
+			}
+
+			root := &node{Name: "root"}
+			tableString, err := TableFromStructs([]node{*root, {Name: "leaf", Parent: root}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`Name
+────
+root
+leaf
+`))
+
+			tableString, err = TableFromStructs([]tree{{Root: node{Name: "a"}}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`Root.Name
+─────────
+a
+`))
+		})
+	})
+})
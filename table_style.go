@@ -0,0 +1,127 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// cellStyler returns the styles to be applied to the cell in the given body
+// row and column, the cell value is passed without text styles
+type cellStyler func(row int, col int, cell string) []bunt.StyleOption
+
+// ColumnColor sets the text color of the body cells of the given columns
+// (referenced by index)
+func ColumnColor(color colorful.Color, cols ...int) TableOption {
+	return func(opts *options) {
+		var selected = map[int]struct{}{}
+		opts.forEachColumn(cols, func(col int) {
+			selected[col] = struct{}{}
+		})
+
+		opts.cellStylers = append(opts.cellStylers, func(_ int, col int, _ string) []bunt.StyleOption {
+			if _, ok := selected[col]; ok {
+				return []bunt.StyleOption{bunt.Foreground(color)}
+			}
+
+			return nil
+		})
+	}
+}
+
+// ZebraStripes applies the styles to every other body row to make wide tables
+// easier to follow
+func ZebraStripes(styles ...bunt.StyleOption) TableOption {
+	return func(opts *options) {
+		opts.cellStylers = append(opts.cellStylers, func(row int, _ int, _ string) []bunt.StyleOption {
+			if row%2 == 1 {
+				return styles
+			}
+
+			return nil
+		})
+	}
+}
+
+// StyleCells applies the styles to the body cells of the given column
+// (referenced by index) for which the condition returns true
+func StyleCells(col int, condition func(cell string) bool, styles ...bunt.StyleOption) TableOption {
+	return func(opts *options) {
+		opts.forEachColumn([]int{col}, func(int) {})
+		opts.cellStylers = append(opts.cellStylers, func(_ int, y int, cell string) []bunt.StyleOption {
+			if y == col && condition(cell) {
+				return styles
+			}
+
+			return nil
+		})
+	}
+}
+
+// ColumnGradient colors the numeric body cells of the given column (referenced
+// by index) with a color between the two colors, based on where the value is
+// located between the lower and upper threshold
+func ColumnGradient(col int, lower float64, upper float64, from colorful.Color, to colorful.Color) TableOption {
+	return func(opts *options) {
+		opts.forEachColumn([]int{col}, func(int) {})
+		opts.cellStylers = append(opts.cellStylers, func(_ int, y int, cell string) []bunt.StyleOption {
+			if y != col {
+				return nil
+			}
+
+			value, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+			if err != nil || upper <= lower {
+				return nil
+			}
+
+			position := math.Min(math.Max((value-lower)/(upper-lower), 0), 1)
+			return []bunt.StyleOption{bunt.Foreground(from.BlendLab(to, position).Clamped())}
+		})
+	}
+}
+
+// styleRow applies the configured cell styles to the body row with the given
+// index, it returns the row unchanged if there are no styles to apply
+func (opts options) styleRow(row []string, idx int) []string {
+	if len(opts.cellStylers) == 0 {
+		return row
+	}
+
+	var styled = make([]string, len(row))
+	for y, cell := range row {
+		var styles []bunt.StyleOption
+		for _, styler := range opts.cellStylers {
+			styles = append(styles, styler(idx, y, plainCell(cell))...)
+		}
+
+		styled[y] = cell
+		if len(styles) > 0 && len(cell) > 0 {
+			styled[y] = bunt.Style(cell, styles...)
+		}
+	}
+
+	return styled
+}
@@ -0,0 +1,95 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/bunt"
+	. "github.com/gonvenience/neat"
+)
+
+var _ = Describe("Table styles", func() {
+	BeforeEach(func() {
+		SetColorSettings(ON, ON)
+	})
+
+	AfterEach(func() {
+		SetColorSettings(AUTO, AUTO)
+	})
+
+	var table = [][]string{
+		{"build", "status", "duration"},
+		{"#1", "Passed", "10"},
+		{"#2", "Failed", "50"},
+		{"#3", "Passed", "100"},
+	}
+
+	Context("Styling columns and rows", func() {
+		It("should color the body cells of the given columns", func() {
+			tableString, err := Table(table, HeaderRows(1), HeaderStyle(), ColumnColor(Gray, 0))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo("build status duration\n" +
+				"─────────────────────\n" +
+				Style("#1", Foreground(Gray)) + "    Passed 10\n" +
+				Style("#2", Foreground(Gray)) + "    Failed 50\n" +
+				Style("#3", Foreground(Gray)) + "    Passed 100\n"))
+		})
+
+		It("should apply zebra stripes to every other body row", func() {
+			tableString, err := Table(table[1:], ZebraStripes(Italic()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo("#1 Passed 10\n" +
+				Style("#2", Italic()) + " " + Style("Failed", Italic()) + " " + Style("50", Italic()) + "\n" +
+				"#3 Passed 100\n"))
+		})
+	})
+
+	Context("Styling cells based on their value", func() {
+		It("should style cells matching the condition", func() {
+			tableString, err := Table(table[1:], StyleCells(1, func(cell string) bool { return cell == "Failed" }, Foreground(Red), Bold()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo("#1 Passed 10\n" +
+				"#2 " + Style("Failed", Foreground(Red), Bold()) + " 50\n" +
+				"#3 Passed 100\n"))
+		})
+
+		It("should color numeric cells using a gradient between thresholds", func() {
+			tableString, err := Table(table[1:], ColumnGradient(2, 10, 100, Green, Red))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo("#1 Passed " + Style("10", Foreground(Green)) + "\n" +
+				"#2 Failed " + Style("50", Foreground(Green.BlendLab(Red, 40.0/90.0).Clamped())) + "\n" +
+				"#3 Passed " + Style("100", Foreground(Red)) + "\n"))
+		})
+
+		It("should keep the raw data plain for exports", func() {
+			result, err := TableCSV(table, ColumnColor(Gray, 0), ZebraStripes(Bold()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEquivalentTo("build,status,duration\n#1,Passed,10\n#2,Failed,50\n#3,Passed,100\n"))
+		})
+
+		It("should fail for a column index out of bounds", func() {
+			_, err := Table(table, StyleCells(3, func(string) bool { return true }, Bold()))
+			Expect(err).To(BeAssignableToTypeOf(&ColumnIndexIsOutOfBoundsError{}))
+		})
+	})
+})
@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	. "github.com/gonvenience/bunt"
 	. "github.com/gonvenience/neat"
 )
 
@@ -125,8 +126,15 @@ uno   dos tres  cuatro cinco
 			}
 
 			tableString, err := Table(input, DesiredWidth(120))
-			Expect(err).Should(MatchError(&RowLengthExceedsDesiredWidthError{}))
+			Expect(err).Should(MatchError(&RowLengthExceedsDesiredWidthError{Required: 215, Desired: 120}))
 			Expect(tableString).To(BeEquivalentTo(""))
+
+			_, err = Table([][]string{{"ab", "cd"}}, DesiredWidth(4))
+			Expect(err).Should(MatchError(&RowLengthExceedsDesiredWidthError{Required: 5, Desired: 4}))
+
+			tableString, err = Table([][]string{{"ab", "cd"}}, DesiredWidth(5))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo("ab cd\n"))
 		})
 
 		It("should error if empty input is provided", func() {
@@ -140,7 +148,8 @@ uno   dos tres  cuatro cinco
 				{"eins", "zwei", "drei", "vier", "fünf"},
 				{"one", "two", "three", "four"},
 			})
-			Expect(err).Should(MatchError(&ImbalancedTableError{}))
+			Expect(err).Should(MatchError(&ImbalancedTableError{Row: 1, Expected: 5, Actual: 4}))
+			Expect(err).Should(MatchError("unable to render table, row 1 has 4 columns, but 5 columns are expected"))
 			Expect(tableString).To(BeEquivalentTo(""))
 		})
 
@@ -153,6 +162,20 @@ uno   dos tres  cuatro cinco
 			Expect(tableString).To(BeEquivalentTo(""))
 		})
 
+		It("should report all errors of the table options", func() {
+			tableString, err := Table([][]string{
+				{"eins", "zwei", "drei", "vier"},
+				{"one", "two", "three", "four"},
+			}, AlignCenter(4), AlignRight(7), SpanRows(5, 0, 2))
+			Expect(err).Should(HaveOccurred())
+			Expect(err.(interface{ Unwrap() []error }).Unwrap()).To(Equal([]error{
+				&ColumnIndexIsOutOfBoundsError{4},
+				&ColumnIndexIsOutOfBoundsError{7},
+				&RowIndexIsOutOfBoundsError{5},
+			}))
+			Expect(tableString).To(BeEquivalentTo(""))
+		})
+
 		It("should be possible to create a table without a final linefeed", func() {
 			input := [][]string{
 				{"eins", "zwei", "drei"},
@@ -203,4 +226,515 @@ un   deux trois
 			Expect(tableString).To(BeEquivalentTo(expectedResult))
 		})
 	})
+
+	Context("Process tables with borders", func() {
+		var input = [][]string{
+			{"eins", "zwei", "drei"},
+			{"one", "two", "three"},
+			{"un", "deux", "trois"},
+		}
+
+		It("should render a table with a rounded border", func() {
+			tableString, err := Table(input, TableBorder(RoundedBorder))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`╭──────┬──────┬───────╮
+│ eins │ zwei │ drei  │
+│ one  │ two  │ three │
+│ un   │ deux │ trois │
+╰──────┴──────┴───────╯
+`))
+		})
+
+		It("should render a table with an ASCII border and row separators", func() {
+			tableString, err := Table(input, TableBorder(ASCIIBorder), RowSeparators(), AlignRight(1))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`+------+------+-------+
+| eins | zwei | drei  |
++------+------+-------+
+| one  |  two | three |
++------+------+-------+
+| un   | deux | trois |
++------+------+-------+
+`))
+		})
+
+		It("should render a table as a Markdown table", func() {
+			tableString, err := Table(input, TableBorder(MarkdownBorder))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`| eins | zwei | drei  |
+| ---- | ---- | ----- |
+| one  | two  | three |
+| un   | deux | trois |
+`))
+		})
+
+		It("should escape cells and set the alignment of Markdown tables", func() {
+			tableString, err := Table([][]string{{"a|b", "c"}, {"multi\nline", "1"}}, TableBorder(MarkdownBorder), AlignRight(1))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`| a\|b          | c   |
+| ------------- | --: |
+| multi<br>line | 1   |
+`))
+		})
+
+		It("should render row separators for tables without a border", func() {
+			tableString, err := Table(input, RowSeparators(), OmitLinefeedAtTableEnd())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`eins zwei drei
+───────────────
+one  two  three
+───────────────
+un   deux trois`))
+		})
+	})
+
+	Context("Process tables with header rows", func() {
+		var input = [][]string{
+			{"Name", "Value"},
+			{"one", "1"},
+			{"two", "2"},
+			{"three", "3"},
+		}
+
+		It("should render a separator beneath the header rows", func() {
+			tableString, err := Table(input, HeaderRows(1), TableBorder(SingleLineBorder))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`┌───────┬───────┐
+│ Name  │ Value │
+├───────┼───────┤
+│ one   │ 1     │
+│ two   │ 2     │
+│ three │ 3     │
+└───────┴───────┘
+`))
+		})
+
+		It("should always render the header rows when the table is truncated", func() {
+			tableString, err := Table(input, HeaderRows(1), LimitRows(1))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`Name Value
+──────────
+one  1
+[...]
+`))
+		})
+
+		It("should repeat the header rows after the given number of rows", func() {
+			tableString, err := Table(input, HeaderRows(1), RepeatHeader(2))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`Name  Value
+───────────
+one   1
+two   2
+Name  Value
+───────────
+three 3
+`))
+		})
+
+		It("should style the header rows", func() {
+			SetColorSettings(ON, ON)
+			defer SetColorSettings(AUTO, AUTO)
+
+			tableString, err := Table(input[:2], HeaderRows(1), HeaderStyle(Bold(), Foreground(CornflowerBlue)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(Sprint("*CornflowerBlue{Name}* *CornflowerBlue{Value}*") + "\n──────────\none  1\n"))
+		})
+	})
+
+	Context("Process tables that need to fit into a given width", func() {
+		var input = [][]string{
+			{"#1", "Lorem ipsum dolor sit amet, consetetur sadipscing elitr", "done"},
+			{"#2", "Stet clita kasd gubergren", "open"},
+		}
+
+		It("should word-wrap cells to fit into the width", func() {
+			tableString, err := Table(input, ShrinkToWidth(40), VertialBarSeparator())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`#1 │ Lorem ipsum dolor sit amet,  │ done
+   │ consetetur sadipscing elitr  │
+#2 │ Stet clita kasd gubergren    │ open
+`))
+		})
+
+		It("should truncate cells with an ellipsis if configured", func() {
+			tableString, err := Table(input, ShrinkToWidth(30), TruncateColumns(1))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`#1 Lorem ipsum dolor sit… done
+#2 Stet clita kasd guber… open
+`))
+		})
+
+		It("should shrink the columns with the lowest priority first", func() {
+			tableString, err := Table([][]string{
+				{"first column", "second column"},
+				{"a", "b"},
+			}, ShrinkToWidth(20), ColumnPriority(1, 1))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`first  second column
+column
+a      b
+`))
+		})
+
+		It("should not shrink columns below their minimum width", func() {
+			tableString, err := Table([][]string{
+				{"name", "description"},
+				{"neat", "convenience functions"},
+			}, ShrinkToWidth(16), ColumnPriority(1, 1), ColumnMinWidth(4, 0))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`name description
+neat convenience
+     functions
+`))
+		})
+
+		It("should limit columns to their maximum width", func() {
+			tableString, err := Table([][]string{
+				{"name", "description"},
+				{"neat", "convenience functions"},
+			}, ColumnMaxWidth(11, 1))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`name description
+neat convenience
+     functions
+`))
+		})
+
+		It("should error if the table cannot be shrunk to fit into the width", func() {
+			_, err := Table(input, ShrinkToWidth(30), ColumnMinWidth(30, 1))
+			Expect(err).To(MatchError(&RowLengthExceedsDesiredWidthError{Required: 34, Desired: 30}))
+		})
+	})
+
+	Context("Process tables with multi-line cells", func() {
+		It("should render multi-line cells as multiple aligned lines", func() {
+			tableString, err := Table([][]string{
+				{"name", "spec"},
+				{"deployment", "replicas: 3\nselector:\n  app: neat"},
+				{"service", "type: ClusterIP"},
+			}, TableBorder(SingleLineBorder), HeaderRows(1), HeaderStyle())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`┌────────────┬─────────────────┐
+│ name       │ spec            │
+├────────────┼─────────────────┤
+│ deployment │ replicas: 3     │
+│            │ selector:       │
+│            │   app: neat     │
+│ service    │ type: ClusterIP │
+└────────────┴─────────────────┘
+`))
+		})
+
+		It("should apply text styles to each line of a multi-line cell", func() {
+			SetColorSettings(ON, ON)
+			defer SetColorSettings(AUTO, AUTO)
+
+			tableString, err := Table([][]string{
+				{"error", Sprint("Red{first line\nsecond line}")},
+			}, OmitLinefeedAtTableEnd())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo("error " + Sprint("Red{first line}") + "\n      " + Sprint("Red{second line}")))
+		})
+	})
+
+	Context("Process tables with characters of different display widths", func() {
+		It("should align columns based on the display width", func() {
+			tableString, err := Table([][]string{
+				{"language", "greeting", "mood"},
+				{"English", "Hello", "🙂"},
+				{"Japanese", "こんにちは", "👩‍💻"},
+				{"German", "Grüß Gott", "😀"},
+			}, VertialBarSeparator(), AlignCenter(2))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`language │ greeting   │ mood
+English  │ Hello      │  🙂
+Japanese │ こんにちは │  👩‍💻
+German   │ Grüß Gott  │  😀
+`))
+		})
+
+		It("should wrap and truncate based on the display width", func() {
+			tableString, err := Table([][]string{
+				{"#1", "日本語のテキスト"},
+				{"#2", "日本語のテキスト"},
+			}, ColumnMaxWidth(9, 1), TruncateColumns(1), LimitRows(1))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`#1 日本語の…
+[...]
+`))
+		})
+
+		It("should wrap wide characters that do not fit into a single column cell", func() {
+			tableString, err := Table([][]string{{"a", "日本"}}, ColumnMaxWidth(1, 1))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo("a 日\n  本\n"))
+
+			_, err = Table([][]string{{"日本"}}, ShrinkToWidth(1))
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+	Context("Process tables with a limited number of rows", func() {
+		var input = [][]string{
+			{"#", "name"},
+			{"1", "alpha"},
+			{"2", "bravo"},
+			{"3", "charlie"},
+			{"4", "delta"},
+			{"5", "echo"},
+		}
+
+		It("should keep the last rows", func() {
+			tableString, err := Table(input, HeaderRows(1), HeaderStyle(), LimitRows(2), RowTruncation(KeepTail))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`# name
+───────
+[...]
+4 delta
+5 echo
+`))
+		})
+
+		It("should keep the first and last rows with a custom marker in between", func() {
+			tableString, err := Table(input, HeaderRows(1), HeaderStyle(), LimitRows(2), RowTruncation(KeepHeadAndTail), TruncationMarker("… %d more rows"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`# name
+───────
+1 alpha
+… 3 more rows
+5 echo
+`))
+		})
+
+		It("should keep other percent signs of the truncation marker", func() {
+			tableString, err := Table(input, HeaderRows(1), HeaderStyle(), LimitRows(2), TruncationMarker("%d rows (100%)"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`# name
+───────
+1 alpha
+2 bravo
+3 rows (100%)
+`))
+		})
+
+		It("should align the marker with the table border", func() {
+			tableString, err := Table(input, TableBorder(SingleLineBorder), LimitRows(2), TruncationMarker("…"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`┌───┬───────┐
+│ # │ name  │
+│ 1 │ alpha │
+│ …         │
+└───────────┘
+`))
+		})
+	})
+
+	Context("Process tables with merged cells", func() {
+		var input = [][]string{
+			{"", "Before", "", "After", ""},
+			{"name", "cpu", "mem", "cpu", "mem"},
+			{"api", "1", "512", "2", "1024"},
+			{"worker", "4", "2048", "4", "4096"},
+		}
+
+		It("should render cells spanning multiple columns", func() {
+			tableString, err := Table(input, HeaderRows(2), HeaderStyle(), SpanColumns(0, 1, 2), SpanColumns(0, 3, 2), AlignCenter(1, 3), TableBorder(SingleLineBorder))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`┌────────┬────────────┬────────────┐
+│        │   Before   │   After    │
+│ name   │ cpu │ mem  │ cpu │ mem  │
+├────────┼─────┼──────┼─────┼──────┤
+│ api    │  1  │ 512  │  2  │ 1024 │
+│ worker │  4  │ 2048 │  4  │ 4096 │
+└────────┴─────┴──────┴─────┴──────┘
+`))
+		})
+
+		It("should distribute the width of a wide merged cell across its columns", func() {
+			tableString, err := Table([][]string{
+				{"a long merged cell", ""},
+				{"x", "y"},
+			}, HeaderRows(1), HeaderStyle(), SpanColumns(0, 0, 2), TableBorder(SingleLineBorder))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`┌────────────────────┐
+│ a long merged cell │
+├──────────┬─────────┤
+│ x        │ y       │
+└──────────┴─────────┘
+`))
+		})
+
+		It("should render cells spanning multiple rows", func() {
+			tableString, err := Table([][]string{
+				{"zone-a", "node1"},
+				{"", "node2"},
+				{"zone-b", "node3"},
+			}, SpanRows(0, 0, 2), RowSeparators(), TableBorder(SingleLineBorder))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`┌────────┬───────┐
+│ zone-a │ node1 │
+│        ├───────┤
+│        │ node2 │
+├────────┼───────┤
+│ zone-b │ node3 │
+└────────┴───────┘
+`))
+		})
+
+		It("should fail for merged cells out of bounds", func() {
+			_, err := Table(input, SpanColumns(0, 4, 2))
+			Expect(err).To(BeAssignableToTypeOf(&ColumnIndexIsOutOfBoundsError{}))
+
+			_, err = Table(input, SpanRows(4, 0, 2))
+			Expect(err).To(BeAssignableToTypeOf(&RowIndexIsOutOfBoundsError{}))
+		})
+
+		It("should only merge the cells of the referenced row if rows are shared", func() {
+			row := []string{"a", "b"}
+			tableString, err := Table([][]string{{"h1", "h2"}, row, row}, SpanColumns(1, 0, 2))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`h1 h2
+a
+a  b
+`))
+		})
+
+		It("should render tables without columns", func() {
+			tableString, err := Table([][]string{{}, {}}, TableBorder(RoundedBorder), RowSeparators())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo("╭──╮\n│  │\n├──┤\n│  │\n╰──╯\n"))
+
+			tableString, err = Table([][]string{{}, {}}, TableBorder(BorderStyle{Vertical: "|", Middle: "-"}), HeaderRows(1))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo("\n\n\n"))
+		})
+
+		It("should fail for overlapping merged cells", func() {
+			_, err := Table([][]string{{"a", "b", "c"}}, SpanColumns(0, 0, 2), SpanColumns(0, 1, 2), TableBorder(RoundedBorder))
+			Expect(err).To(MatchError(&OverlappingCellSpansError{RowIdx: 0, ColumnIdx: 1}))
+
+			_, err = Table([][]string{{"a", "b"}, {"c", "d"}}, SpanRows(0, 1, 2), SpanColumns(1, 0, 2))
+			Expect(err).To(MatchError(&OverlappingCellSpansError{RowIdx: 1, ColumnIdx: 0}))
+
+			_, err = Table([][]string{{"a", "b"}, {"c", "d"}}, SpanRows(0, 0, 2), SpanColumns(0, 0, 2))
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("Process tables with decimal alignment", func() {
+		It("should align numbers on their decimal point", func() {
+			tableString, err := Table([][]string{
+				{"name", "value"},
+				{"a", "1.5"},
+				{"b", "128"},
+				{"c", "12.25"},
+				{"d", "n/a"},
+			}, HeaderRows(1), HeaderStyle(), AlignDecimal(1))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`name  value
+───────────
+a      1.5
+b    128
+c     12.25
+d       n/a
+`))
+		})
+
+		It("should align unit suffixes", func() {
+			tableString, err := Table([][]string{
+				{"api", "1.5 MiB", "12ms"},
+				{"worker", "512 KiB", "1.25s"},
+			}, AlignUnits(1, 2), TableBorder(ASCIIBorder))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`+--------+-----------+---------+
+| api    |   1.5 MiB | 12   ms |
+| worker | 512   KiB |  1.25s  |
++--------+-----------+---------+
+`))
+		})
+
+		It("should align unit suffixes regardless of the space before them", func() {
+			tableString, err := Table([][]string{
+				{"1.5 MiB"},
+				{"3.25ms"},
+				{".5"},
+			}, AlignUnits(0), TableBorder(ASCIIBorder))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`+----------+
+| 1.5  MiB |
+| 3.25 ms  |
+|  .5      |
++----------+
+`))
+		})
+
+		It("should keep unit suffixes next to the number by default", func() {
+			tableString, err := Table([][]string{
+				{"1.5 MiB"},
+				{"3.25ms"},
+				{".5"},
+			}, AlignDecimal(0), TableBorder(ASCIIBorder))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`+---------+
+| 1.5 MiB |
+| 3.25ms  |
+|  .5     |
++---------+
+`))
+		})
+	})
+
+	Context("Process tables with the vertical layout", func() {
+		var input = [][]string{
+			{"name", "status", "restarts"},
+			{"api", "Running", "0"},
+			{"worker", "CrashLoopBackOff", "12"},
+		}
+
+		It("should render each row as a block of header and value lines", func() {
+			tableString, err := Table(input, HeaderRows(1), HeaderStyle(), AlignRight(2), VerticalLayout())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`name:     api
+status:   Running
+restarts:                0
+──────────────────────────
+name:     worker
+status:   CrashLoopBackOff
+restarts:               12
+`))
+		})
+
+		It("should use the column numbers as keys without header rows", func() {
+			tableString, err := Table(input[1:2], VerticalLayout(), TableBorder(RoundedBorder))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`╭────┬─────────╮
+│ 1: │ api     │
+│ 2: │ Running │
+│ 3: │ 0       │
+╰────┴─────────╯
+`))
+		})
+
+		It("should only use the vertical layout if the table is too wide", func() {
+			tableString, err := Table(input, HeaderRows(1), HeaderStyle(), VerticalLayoutFallback(40))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`name   status           restarts
+────────────────────────────────
+api    Running          0
+worker CrashLoopBackOff 12
+`))
+
+			tableString, err = Table(input, HeaderRows(1), HeaderStyle(), VerticalLayoutFallback(20))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`name:     api
+status:   Running
+restarts: 0
+────────────────────
+name:     worker
+status:   CrashLoopB
+          ackOff
+restarts: 12
+`))
+		})
+	})
 })
@@ -0,0 +1,85 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import "strings"
+
+// Tree connectors used to indent the first cell of nested rows
+const (
+	treeBranch   = "├─ "
+	treeLast     = "└─ "
+	treeVertical = "│  "
+	treeSpace    = "   "
+)
+
+// TreeRow is a table row with nested rows, which are rendered below it
+type TreeRow struct {
+	Cells    []string
+	Children []TreeRow
+}
+
+// TreeTable renders the rows as a table, where the first column shows the
+// hierarchy of the rows using tree connectors, while the other columns stay
+// aligned. Top-level rows are not indented, so that header rows can be
+// defined as top-level rows without children (see HeaderRows). The first
+// column is truncated instead of word-wrapped when it does not fit, since the
+// wrapped lines would interrupt the tree connectors.
+func TreeTable(rows []TreeRow, tableOptions ...TableOption) (string, error) {
+	var table = [][]string{}
+	for _, row := range rows {
+		table = flattenTreeRow(table, row, "", "")
+	}
+
+	return Table(table, append(tableOptions, TruncateColumns(0))...)
+}
+
+// flattenTreeRow appends the row and its nested rows to the table, where the
+// connector is put in front of the first cell of the row, and the indent in
+// front of the first cell of all nested rows
+func flattenTreeRow(table [][]string, row TreeRow, connector string, indent string) [][]string {
+	var cells = append([]string{}, row.Cells...)
+	if len(cells) > 0 {
+		// Continuation lines of multi-line cells are indented so that the
+		// connectors of the following rows are not interrupted
+		lines := strings.Split(cells[0], "\n")
+		for i := range lines {
+			if i == 0 {
+				lines[i] = connector + lines[i]
+			} else {
+				lines[i] = indent + lines[i]
+			}
+		}
+
+		cells[0] = strings.Join(lines, "\n")
+	}
+
+	table = append(table, cells)
+
+	for i, child := range row.Children {
+		if i == len(row.Children)-1 {
+			table = flattenTreeRow(table, child, indent+treeLast, indent+treeSpace)
+		} else {
+			table = flattenTreeRow(table, child, indent+treeBranch, indent+treeVertical)
+		}
+	}
+
+	return table
+}
@@ -0,0 +1,107 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/bunt"
+	. "github.com/gonvenience/neat"
+)
+
+var _ = Describe("Tree tables", func() {
+	BeforeEach(func() {
+		SetColorSettings(OFF, OFF)
+	})
+
+	AfterEach(func() {
+		SetColorSettings(AUTO, AUTO)
+	})
+
+	Context("Rendering hierarchical rows", func() {
+		It("should show the hierarchy in the first column using tree connectors", func() {
+			tableString, err := TreeTable([]TreeRow{
+				{Cells: []string{"NAME", "READY"}},
+				{Cells: []string{"deployment/api", "2/2"}, Children: []TreeRow{
+					{Cells: []string{"replicaset/api-5d9", "2/2"}, Children: []TreeRow{
+						{Cells: []string{"pod/api-5d9-abc", "1/1"}},
+						{Cells: []string{"pod/api-5d9-def", "1/1"}},
+					}},
+					{Cells: []string{"replicaset/api-7f4", "0/0"}},
+				}},
+			}, HeaderRows(1), HeaderStyle())
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`NAME                  READY
+───────────────────────────
+deployment/api        2/2
+├─ replicaset/api-5d9 2/2
+│  ├─ pod/api-5d9-abc 1/1
+│  └─ pod/api-5d9-def 1/1
+└─ replicaset/api-7f4 0/0
+`))
+		})
+
+		It("should keep the connectors intact for multi-line cells", func() {
+			tableString, err := TreeTable([]TreeRow{
+				{Cells: []string{"root", "a"}, Children: []TreeRow{
+					{Cells: []string{"first\nchild", "b"}},
+					{Cells: []string{"second", "c"}},
+				}},
+			}, TableBorder(ASCIIBorder))
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`+-----------+---+
+| root      | a |
+| ├─ first  | b |
+| │  child  |   |
+| └─ second | c |
++-----------+---+
+`))
+		})
+
+		It("should truncate the first column instead of wrapping it", func() {
+			tableString, err := TreeTable([]TreeRow{
+				{Cells: []string{"root", "a"}, Children: []TreeRow{
+					{Cells: []string{"child with long name", "b"}},
+					{Cells: []string{"second", "c"}},
+				}},
+			}, ColumnMaxWidth(10, 0))
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tableString).To(BeEquivalentTo(`root       a
+├─ child … b
+└─ second  c
+`))
+		})
+
+		It("should fail for rows with a different number of columns", func() {
+			_, err := TreeTable([]TreeRow{
+				{Cells: []string{"root", "a"}, Children: []TreeRow{
+					{Cells: []string{"child"}},
+				}},
+			})
+
+			Expect(err).To(BeAssignableToTypeOf(&ImbalancedTableError{}))
+		})
+	})
+})
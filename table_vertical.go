@@ -0,0 +1,162 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/term"
+)
+
+// VerticalLayout renders each body row as a block of `header: value` lines,
+// which keeps tables with many columns readable on narrow terminals. The keys
+// are taken from the last header row, or the column numbers if there is none.
+func VerticalLayout() TableOption {
+	return func(opts *options) {
+		opts.vertical = true
+	}
+}
+
+// VerticalLayoutFallback renders the table using the vertical layout only if
+// the table would be wider than the given width (or the terminal width if the
+// given width is zero)
+func VerticalLayoutFallback(width int) TableOption {
+	return func(opts *options) {
+		opts.verticalFallback = true
+		opts.verticalWidth = width
+	}
+}
+
+// exceedsVerticalFallbackWidth returns whether one of the lines is wider than
+// the width at which the vertical layout is used instead
+func (opts options) exceedsVerticalFallbackWidth(lines []string) bool {
+	if !opts.verticalFallback {
+		return false
+	}
+
+	for _, line := range lines {
+		if displayWidth(line) > opts.fallbackWidth() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (opts options) fallbackWidth() int {
+	if opts.verticalWidth > 0 {
+		return opts.verticalWidth
+	}
+
+	return term.GetTerminalWidth()
+}
+
+// renderVertical renders the body and footer rows as blocks of key and value
+// lines, which are separated by a rule
+func renderVertical(sections tableSections, options options) []string {
+	var cols = len(options.columnAlignment)
+
+	var keys = make([]string, cols)
+	for y := range keys {
+		if len(sections.header) > 0 {
+			keys[y] = sections.header[len(sections.header)-1][y] + ":"
+		} else {
+			keys[y] = strconv.Itoa(y+1) + ":"
+		}
+
+		if len(options.headerStyles) > 0 {
+			keys[y] = bunt.Style(keys[y], options.headerStyles...)
+		}
+	}
+
+	var records = make([][]string, 0, len(sections.body)+len(sections.footer))
+	for idx, row := range sections.body {
+		records = append(records, options.styleRow(row, idx))
+	}
+
+	records = append(records, sections.footer...)
+
+	// The vertical layout consists of two columns, the keys and the values
+	var maxs = make([]int, 2)
+	for y, key := range keys {
+		maxs[0] = max(maxs[0], cellWidth(key))
+		for _, record := range records {
+			maxs[1] = max(maxs[1], cellWidth(record[y]))
+		}
+	}
+
+	vertical := options
+	vertical.columnAlignment = []Alignment{Left, Left}
+	vertical.columnOverflow = []Overflow{Wrap, Wrap}
+	vertical.decimals = nil
+
+	if options.verticalFallback {
+		available := options.fallbackWidth() - maxs[0] - displayWidth(options.separator) - options.frameWidth()
+		maxs[1] = max(1, min(maxs[1], available))
+	}
+
+	var (
+		layout = plainLayout(2)
+		lines  = []string{}
+	)
+
+	if len(options.border.Top) > 0 {
+		lines = append(lines, renderRule(maxs, vertical, topRule, layout, layout))
+	}
+
+	for idx, record := range records {
+		if idx > 0 {
+			lines = append(lines, renderRule(maxs, vertical, middleRule, layout, layout))
+		}
+
+		for y, value := range record {
+			// Values keep the alignment of their column, where numbers are
+			// aligned to the right since there is no common decimal point
+			alignment := options.columnAlignment[y]
+			if alignment == Decimal {
+				alignment = Right
+			}
+
+			vertical.columnAlignment = []Alignment{Left, alignment}
+			vertical.columnOverflow = []Overflow{Wrap, options.columnOverflow[y]}
+			lines = append(lines, renderRow([]string{keys[y], value}, layout, maxs, vertical)...)
+		}
+	}
+
+	if sections.omitted > 0 {
+		lines = append(lines, renderSpanningLine(options.marker(sections.omitted), maxs, vertical))
+	}
+
+	if len(options.border.Bottom) > 0 {
+		lines = append(lines, renderRule(maxs, vertical, bottomRule, layout, layout))
+	}
+
+	// Right aligned values of unframed tables must not leave trailing whitespace
+	if len(options.border.Right) == 0 {
+		for i := range lines {
+			lines[i] = strings.TrimRight(lines[i], " ")
+		}
+	}
+
+	return lines
+}
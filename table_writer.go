@@ -0,0 +1,281 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gonvenience/bunt"
+)
+
+// TableWriter renders table rows as they arrive to a writer, which is useful
+// for unbounded row sources like watch output. Column widths are either
+// configured, sampled from the first rows, or grow with the rows written. The
+// header row is rendered again whenever the column widths have to grow.
+type TableWriter struct {
+	out          io.Writer
+	header       []string
+	tableOptions []TableOption
+	options      options
+	err          error
+
+	widths   []int
+	maxs     []int
+	decimals []decimalLayout
+	sample   int
+	rows     int
+	buffer   [][]string
+	started  bool
+	closed   bool
+}
+
+// NewTableWriter creates a table writer for rows with the given header, in
+// case the header is nil, no header row is rendered. Without a header row, the
+// rows rendered before the columns grow are not aligned with the following
+// rows, so ColumnWidths or SampleRows should be used to size the columns.
+func NewTableWriter(out io.Writer, header []string, tableOptions ...TableOption) *TableWriter {
+	return &TableWriter{
+		out:          out,
+		header:       header,
+		tableOptions: tableOptions,
+	}
+}
+
+// ColumnWidths sets the initial widths of the columns, wider cells still make
+// the columns grow
+func (w *TableWriter) ColumnWidths(widths ...int) *TableWriter {
+	w.widths = widths
+	return w
+}
+
+// SampleRows buffers the given number of rows before rendering anything, so
+// that the column widths are based on these rows and are less likely to grow
+func (w *TableWriter) SampleRows(rows int) *TableWriter {
+	w.sample = rows
+	return w
+}
+
+// WriteRow writes a row with one value per column, depending on the number of
+// sample rows, the row is buffered until enough rows are written
+func (w *TableWriter) WriteRow(row ...string) error {
+	if w.closed {
+		return &ClosedTableWriterError{}
+	}
+
+	if w.maxs == nil {
+		w.init(len(row))
+	}
+
+	if w.err != nil {
+		return w.err
+	}
+
+	if w.options.lenientRows {
+		row = w.options.balanceRow(row, len(w.maxs))
+	}
+
+	if len(row) != len(w.maxs) {
+		return &ImbalancedTableError{Row: w.rows, Expected: len(w.maxs), Actual: len(row)}
+	}
+
+	w.rows++
+	w.buffer = append(w.buffer, row)
+	if len(w.buffer) < w.sample {
+		return nil
+	}
+
+	return w.Flush()
+}
+
+// Flush renders all buffered rows
+func (w *TableWriter) Flush() error {
+	if w.closed {
+		return &ClosedTableWriterError{}
+	}
+
+	if w.err != nil || len(w.buffer) == 0 {
+		return w.err
+	}
+
+	var rows = w.buffer
+	w.buffer, w.sample = nil, 0
+
+	if grown := w.fit(rows); grown || !w.started {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range rows {
+		if err := w.writeLines(renderRow(row, plainLayout(len(row)), w.maxs, w.options)...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close renders all buffered rows and finishes the table, for example by
+// rendering the bottom border, no rows can be written afterwards
+func (w *TableWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	w.closed = true
+	if w.started && len(w.options.border.Bottom) > 0 {
+		return w.writeLines(w.rule(bottomRule))
+	}
+
+	return nil
+}
+
+// init applies the table options once the number of columns is known
+func (w *TableWriter) init(cols int) {
+	if w.header != nil {
+		cols = len(w.header)
+	}
+
+	// Like in tables, the header row is bold unless configured otherwise
+	w.options = defaultOptions(cols)
+	if w.header != nil {
+		HeaderRows(1)(&w.options)
+	}
+
+	for _, userOption := range w.tableOptions {
+		userOption(&w.options)
+	}
+
+	w.maxs = make([]int, cols)
+	w.decimals = make([]decimalLayout, cols)
+
+	switch {
+	case len(w.options.errors) > 0:
+		w.err = joinErrors(w.options.errors)
+
+	case w.widths != nil && len(w.widths) != cols:
+		w.err = &ColumnWidthsMismatchError{Columns: cols, Widths: len(w.widths)}
+
+	case w.widths != nil:
+		copy(w.maxs, w.widths)
+	}
+
+	if w.header != nil && w.err == nil {
+		w.fit([][]string{w.header})
+	}
+}
+
+// fit updates the column widths to fit the rows, it returns whether at least
+// one column had to grow
+func (w *TableWriter) fit(rows [][]string) bool {
+	var (
+		maxs  = append([]int{}, w.maxs...)
+		grown bool
+	)
+
+	for _, row := range rows {
+		for y, cell := range row {
+			maxs[y] = max(maxs[y], cellWidth(cell))
+		}
+	}
+
+	for y, decimal := range lookupDecimalLayouts(rows, maxs, w.options) {
+		decimal.integer = max(decimal.integer, w.decimals[y].integer)
+		decimal.fraction = max(decimal.fraction, w.decimals[y].fraction)
+		decimal.space = max(decimal.space, w.decimals[y].space)
+		decimal.suffix = max(decimal.suffix, w.decimals[y].suffix)
+		if decimal != w.decimals[y] {
+			w.decimals[y] = decimal
+			maxs[y] = max(maxs[y], decimal.width())
+			grown = true
+		}
+	}
+
+	for y, max := range w.options.columnMaxWidth {
+		if max > 0 && maxs[y] > max {
+			maxs[y] = max
+		}
+	}
+
+	for y := range maxs {
+		if maxs[y] > w.maxs[y] {
+			w.maxs[y] = maxs[y]
+			grown = true
+		}
+	}
+
+	w.options.decimals = w.decimals
+	return grown
+}
+
+// writeHeader renders the top border and the header row, in case the table
+// was already started, the current table is closed first
+func (w *TableWriter) writeHeader() error {
+	var lines []string
+	if w.started {
+		if w.header == nil {
+			return nil
+		}
+
+		if len(w.options.border.Bottom) > 0 {
+			lines = append(lines, w.rule(bottomRule))
+		}
+	}
+
+	if len(w.options.border.Top) > 0 {
+		lines = append(lines, w.rule(topRule))
+	}
+
+	if w.header != nil {
+		var header = w.header
+		if len(w.options.headerStyles) > 0 {
+			header = make([]string, len(w.header))
+			for y, cell := range w.header {
+				header[y] = bunt.Style(cell, w.options.headerStyles...)
+			}
+		}
+
+		lines = append(lines, renderRow(header, plainLayout(len(header)), w.maxs, w.options)...)
+		lines = append(lines, w.rule(middleRule))
+	}
+
+	w.started = true
+	return w.writeLines(lines...)
+}
+
+func (w *TableWriter) rule(kind ruleKind) string {
+	return renderRule(w.maxs, w.options, kind, plainLayout(len(w.maxs)), plainLayout(len(w.maxs)))
+}
+
+func (w *TableWriter) writeLines(lines ...string) error {
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w.out, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/bunt"
+	. "github.com/gonvenience/neat"
+)
+
+var _ = Describe("Table writer", func() {
+	var buf *bytes.Buffer
+
+	BeforeEach(func() {
+		SetColorSettings(OFF, OFF)
+		buf = &bytes.Buffer{}
+	})
+
+	AfterEach(func() {
+		SetColorSettings(AUTO, AUTO)
+	})
+
+	Context("Writing rows as they arrive", func() {
+		It("should render the header again when the columns have to grow", func() {
+			w := NewTableWriter(buf, []string{"name", "status"})
+			Expect(w.WriteRow("api", "Running")).To(Succeed())
+			Expect(buf.String()).To(Equal(`name status
+────────────
+api  Running
+`))
+
+			Expect(w.WriteRow("worker", "Pending")).To(Succeed())
+			Expect(w.WriteRow("cache", "Running")).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+			Expect(buf.String()).To(Equal(`name status
+────────────
+api  Running
+name   status
+──────────────
+worker Pending
+cache  Running
+`))
+		})
+
+		It("should use the sampled rows to determine the column widths", func() {
+			w := NewTableWriter(buf, []string{"name", "status"}).SampleRows(2)
+			Expect(w.WriteRow("api", "Running")).To(Succeed())
+			Expect(buf.String()).To(BeEmpty())
+
+			Expect(w.WriteRow("worker", "Pending")).To(Succeed())
+			Expect(w.WriteRow("cache", "Running")).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+			Expect(buf.String()).To(Equal(`name   status
+──────────────
+api    Running
+worker Pending
+cache  Running
+`))
+		})
+
+		It("should use the configured column widths and close the border", func() {
+			w := NewTableWriter(buf, []string{"name", "cpu"}, TableBorder(ASCIIBorder), AlignRight(1)).ColumnWidths(8, 4)
+			Expect(w.WriteRow("api", "250")).To(Succeed())
+			Expect(w.WriteRow("worker", "1500")).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+			Expect(buf.String()).To(Equal(`+----------+------+
+| name     |  cpu |
++----------+------+
+| api      |  250 |
+| worker   | 1500 |
++----------+------+
+`))
+		})
+
+		It("should render rows without a header, which are not realigned when the columns grow", func() {
+			w := NewTableWriter(buf, nil)
+			Expect(w.WriteRow("a", "1")).To(Succeed())
+			Expect(w.WriteRow("bb", "2")).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+			Expect(buf.String()).To(Equal("a 1\nbb 2\n"))
+		})
+
+		It("should align rows without a header based on the sampled rows", func() {
+			w := NewTableWriter(buf, nil).SampleRows(2)
+			Expect(w.WriteRow("a", "1")).To(Succeed())
+			Expect(w.WriteRow("bb", "2")).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+			Expect(buf.String()).To(Equal("a  1\nbb 2\n"))
+		})
+
+		It("should fail for rows with the wrong number of columns", func() {
+			w := NewTableWriter(buf, []string{"name", "status"})
+			Expect(w.WriteRow("api", "ok")).To(Succeed())
+			Expect(w.WriteRow("db")).To(MatchError(&ImbalancedTableError{Row: 1, Expected: 2, Actual: 1}))
+		})
+
+		It("should fail for column widths that do not match the columns", func() {
+			w := NewTableWriter(buf, []string{"name", "status"}).ColumnWidths(4)
+			Expect(w.WriteRow("api", "ok")).To(MatchError(&ColumnWidthsMismatchError{Columns: 2, Widths: 1}))
+		})
+
+		It("should fail to write rows once the writer is closed", func() {
+			w := NewTableWriter(buf, nil, TableBorder(ASCIIBorder))
+			Expect(w.WriteRow("1", "2")).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+			Expect(w.WriteRow("3", "4")).To(MatchError(&ClosedTableWriterError{}))
+			Expect(w.Flush()).To(MatchError(&ClosedTableWriterError{}))
+			Expect(w.Close()).To(Succeed())
+			Expect(buf.String()).To(Equal(`+---+---+
+| 1 | 2 |
++---+---+
+`))
+		})
+
+		It("should render the header row in bold by default", func() {
+			SetColorSettings(ON, ON)
+
+			w := NewTableWriter(buf, []string{"name"})
+			Expect(w.WriteRow("api")).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+			Expect(buf.String()).To(HavePrefix(Style("name", Bold())))
+
+			buf.Reset()
+			w = NewTableWriter(buf, []string{"name"}, HeaderStyle())
+			Expect(w.WriteRow("api")).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+			Expect(buf.String()).To(HavePrefix("name\n"))
+		})
+
+		It("should fail for invalid options", func() {
+			w := NewTableWriter(buf, []string{"name"}, AlignRight(1))
+			Expect(w.WriteRow("api")).To(BeAssignableToTypeOf(&ColumnIndexIsOutOfBoundsError{}))
+		})
+	})
+})
@@ -0,0 +1,75 @@
+// Copyright © 2019 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package neat
+
+import (
+	"unicode"
+
+	"golang.org/x/text/width"
+
+	"github.com/gonvenience/bunt"
+)
+
+const zeroWidthJoiner = '\u200d'
+
+// displayWidth returns the number of terminal columns that are required to
+// display the provided text, which can contain escape sequences. East Asian
+// wide characters occupy two columns, while combining characters and
+// characters that are joined using a zero width joiner do not add any width.
+func displayWidth(text string) int {
+	var (
+		result int
+		joined bool
+	)
+
+	for _, r := range bunt.RemoveAllEscapeSequences(text) {
+		if !joined {
+			result += runeWidth(r)
+		}
+
+		joined = r == zeroWidthJoiner
+	}
+
+	return result
+}
+
+// runeWidth returns the number of terminal columns of a single character
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7f && r < 0xa0):
+		return 0
+
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+
+	case r >= 0x1160 && r <= 0x11ff:
+		// Hangul Jamo medial vowels and final consonants combine with the
+		// preceding character
+		return 0
+	}
+
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+
+	return 1
+}